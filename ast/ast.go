@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/icholy/cc/token"
+	"github.com/icholy/cc/types"
 )
 
 type Node interface {
@@ -39,13 +40,36 @@ func (p *Program) String() string {
 
 type IntLit struct {
 	Tok   token.Token
-	Value int
+	Value uint64
+	Type  types.Type
 }
 
 func (i *IntLit) exprNode()          {}
 func (i *IntLit) Token() token.Token { return i.Tok }
 func (i *IntLit) String() string     { return fmt.Sprintf("IntLit(%d)", i.Value) }
 
+// CharLit is a character constant. It has type int and the value of
+// its character converted from char.
+type CharLit struct {
+	Tok   token.Token
+	Value int
+}
+
+func (c *CharLit) exprNode()          {}
+func (c *CharLit) Token() token.Token { return c.Tok }
+func (c *CharLit) String() string     { return fmt.Sprintf("CharLit(%s)", c.Tok.Text) }
+
+// StringLit is a string literal. Adjacent literals are concatenated
+// into a single StringLit.
+type StringLit struct {
+	Tok   token.Token
+	Value string
+}
+
+func (s *StringLit) exprNode()          {}
+func (s *StringLit) Token() token.Token { return s.Tok }
+func (s *StringLit) String() string     { return fmt.Sprintf("StringLit(%q)", s.Value) }
+
 type BinaryOp struct {
 	Tok   token.Token
 	Op    string
@@ -68,21 +92,111 @@ func (u *UnaryOp) Token() token.Token { return u.Tok }
 func (u *UnaryOp) String() string     { return fmt.Sprintf("UnaryOp(%s %s)", u.Op, u.Value) }
 
 type Assign struct {
-	Tok   token.Token
-	Var   *Var
-	Value Expr
+	Tok    token.Token
+	Target Expr
+	Value  Expr
 }
 
 func (a *Assign) exprNode()          {}
 func (a *Assign) Token() token.Token { return a.Tok }
-func (a *Assign) String() string     { return fmt.Sprintf("Assign(%s = %s)", a.Var, a.Value) }
+func (a *Assign) String() string     { return fmt.Sprintf("Assign(%s = %s)", a.Target, a.Value) }
 
-type VarDec struct {
+// CompoundAssign is an assignment using an operator such as += or <<=.
+type CompoundAssign struct {
+	Tok    token.Token
+	Op     string
+	Target Expr
+	Value  Expr
+}
+
+func (c *CompoundAssign) exprNode()          {}
+func (c *CompoundAssign) Token() token.Token { return c.Tok }
+func (c *CompoundAssign) String() string {
+	return fmt.Sprintf("CompoundAssign(%s %s %s)", c.Target, c.Op, c.Value)
+}
+
+// IncDec is a prefix or postfix ++ or -- operator.
+type IncDec struct {
+	Tok     token.Token
+	Op      string
+	Postfix bool
+	Target  Expr
+}
+
+func (i *IncDec) exprNode()          {}
+func (i *IncDec) Token() token.Token { return i.Tok }
+func (i *IncDec) String() string {
+	if i.Postfix {
+		return fmt.Sprintf("IncDec(%s%s)", i.Target, i.Op)
+	}
+	return fmt.Sprintf("IncDec(%s%s)", i.Op, i.Target)
+}
+
+// TypeDec is a declaration which only declares a type, such as a
+// struct definition without any declarators or a typedef. Name is
+// only set for typedefs.
+type TypeDec struct {
+	Tok  token.Token
+	Name string
+	Type types.Type
+}
+
+func (t *TypeDec) stmtNode()          {}
+func (t *TypeDec) Token() token.Token { return t.Tok }
+func (t *TypeDec) String() string {
+	if t.Name != "" {
+		return fmt.Sprintf("TypeDec(%s %s)", t.Name, t.Type)
+	}
+	return fmt.Sprintf("TypeDec(%s)", t.Type)
+}
+
+// EnumDec declares the enumerators of an enum specifier.
+type EnumDec struct {
+	Tok         token.Token
+	Tag         string
+	Enumerators []*Enumerator
+}
+
+// Enumerator is an enumeration constant. A nil Value means one more
+// than the previous enumerator, or zero for the first.
+type Enumerator struct {
 	Tok   token.Token
 	Name  string
 	Value Expr
 }
 
+func (e *EnumDec) stmtNode()          {}
+func (e *EnumDec) Token() token.Token { return e.Tok }
+func (e *EnumDec) String() string {
+	names := make([]string, len(e.Enumerators))
+	for i, en := range e.Enumerators {
+		if en.Value == nil {
+			names[i] = en.Name
+		} else {
+			names[i] = fmt.Sprintf("%s = %s", en.Name, en.Value)
+		}
+	}
+	return fmt.Sprintf("EnumDec(%s {%s})", e.Tag, strings.Join(names, ", "))
+}
+
+// Storage is the storage class of a declaration. Auto is used for
+// declarations without a storage class specifier.
+type Storage int
+
+const (
+	Auto Storage = iota
+	Static
+	Extern
+)
+
+type VarDec struct {
+	Tok     token.Token
+	Name    string
+	Type    types.Type
+	Value   Expr
+	Storage Storage
+}
+
 func (v *VarDec) stmtNode()          {}
 func (v *VarDec) Token() token.Token { return v.Tok }
 func (v *VarDec) String() string {
@@ -118,10 +232,12 @@ func (i *If) String() string {
 }
 
 type FuncDec struct {
-	Tok    token.Token
-	Name   string
-	Params []string
-	Body   *Block
+	Tok     token.Token
+	Name    string
+	Type    *types.Func
+	Params  []string
+	Body    *Block
+	Storage Storage
 }
 
 func (f *FuncDec) stmtNode()          {}
@@ -178,7 +294,7 @@ func (t *Ternary) String() string {
 
 type For struct {
 	Tok       token.Token
-	Setup     Stmt
+	Setup     []Stmt
 	Condition Expr
 	Increment Expr
 	Body      Stmt
@@ -210,6 +326,58 @@ func (d *Do) stmtNode()          {}
 func (d *Do) Token() token.Token { return d.Tok }
 func (d *Do) String() string     { return fmt.Sprintf("DO %s WHILE(%s)", d.Body, d.Condition) }
 
+type Switch struct {
+	Tok   token.Token
+	Value Expr
+	Body  Stmt
+}
+
+func (s *Switch) stmtNode()          {}
+func (s *Switch) Token() token.Token { return s.Tok }
+func (s *Switch) String() string     { return fmt.Sprintf("SWITCH(%s) %s", s.Value, s.Body) }
+
+// Case is a statement labeled with a case of the enclosing switch.
+type Case struct {
+	Tok   token.Token
+	Value Expr
+	Body  Stmt
+}
+
+func (c *Case) stmtNode()          {}
+func (c *Case) Token() token.Token { return c.Tok }
+func (c *Case) String() string     { return fmt.Sprintf("CASE %s: %s", c.Value, c.Body) }
+
+// Default is a statement labeled as the default case of the
+// enclosing switch.
+type Default struct {
+	Tok  token.Token
+	Body Stmt
+}
+
+func (d *Default) stmtNode()          {}
+func (d *Default) Token() token.Token { return d.Tok }
+func (d *Default) String() string     { return fmt.Sprintf("DEFAULT: %s", d.Body) }
+
+// Label is a statement labeled with a name which goto can jump to.
+type Label struct {
+	Tok  token.Token
+	Name string
+	Body Stmt
+}
+
+func (l *Label) stmtNode()          {}
+func (l *Label) Token() token.Token { return l.Tok }
+func (l *Label) String() string     { return fmt.Sprintf("%s: %s", l.Name, l.Body) }
+
+type Goto struct {
+	Tok   token.Token
+	Label string
+}
+
+func (g *Goto) stmtNode()          {}
+func (g *Goto) Token() token.Token { return g.Tok }
+func (g *Goto) String() string     { return fmt.Sprintf("GOTO %s", g.Label) }
+
 type Break struct {
 	Tok token.Token
 }
@@ -226,6 +394,17 @@ func (c *Continue) stmtNode()          {}
 func (c *Continue) Token() token.Token { return c.Tok }
 func (c *Continue) String() string     { return "CONTINUE" }
 
+// Comma evaluates Left for its side effects and then yields Right.
+type Comma struct {
+	Tok   token.Token
+	Left  Expr
+	Right Expr
+}
+
+func (c *Comma) exprNode()          {}
+func (c *Comma) Token() token.Token { return c.Tok }
+func (c *Comma) String() string     { return fmt.Sprintf("(%s, %s)", c.Left, c.Right) }
+
 type Null struct {
 	Tok token.Token
 }
@@ -245,9 +424,160 @@ func IsNull(n Node) bool {
 	}
 }
 
+// Cast converts Value to Type.
+type Cast struct {
+	Tok   token.Token
+	Type  types.Type
+	Value Expr
+}
+
+func (c *Cast) exprNode()          {}
+func (c *Cast) Token() token.Token { return c.Tok }
+func (c *Cast) String() string     { return fmt.Sprintf("Cast((%s) %s)", c.Type, c.Value) }
+
+// SizeOf is the size of a Type or of the type of an unevaluated
+// Value expression.
+type SizeOf struct {
+	Tok   token.Token
+	Type  types.Type
+	Value Expr
+}
+
+func (s *SizeOf) exprNode()          {}
+func (s *SizeOf) Token() token.Token { return s.Tok }
+func (s *SizeOf) String() string {
+	if s.Type != nil {
+		return fmt.Sprintf("SizeOf(%s)", s.Type)
+	}
+	return fmt.Sprintf("SizeOf(%s)", s.Value)
+}
+
+// VaStart initializes List to point to the variadic arguments of
+// the current function. Last is its last named parameter.
+type VaStart struct {
+	Tok  token.Token
+	List Expr
+	Last Expr
+}
+
+func (v *VaStart) exprNode()          {}
+func (v *VaStart) Token() token.Token { return v.Tok }
+func (v *VaStart) String() string     { return fmt.Sprintf("VaStart(%s, %s)", v.List, v.Last) }
+
+// VaArg returns the next variadic argument of type Type and
+// advances List past it.
+type VaArg struct {
+	Tok  token.Token
+	List Expr
+	Type types.Type
+}
+
+func (v *VaArg) exprNode()          {}
+func (v *VaArg) Token() token.Token { return v.Tok }
+func (v *VaArg) String() string     { return fmt.Sprintf("VaArg(%s, %s)", v.List, v.Type) }
+
+// VaEnd ends the use of List.
+type VaEnd struct {
+	Tok  token.Token
+	List Expr
+}
+
+func (v *VaEnd) exprNode()          {}
+func (v *VaEnd) Token() token.Token { return v.Tok }
+func (v *VaEnd) String() string     { return fmt.Sprintf("VaEnd(%s)", v.List) }
+
+// Index is a subscript expression: Array[Index].
+type Index struct {
+	Tok   token.Token
+	Array Expr
+	Index Expr
+}
+
+func (i *Index) exprNode()          {}
+func (i *Index) Token() token.Token { return i.Tok }
+func (i *Index) String() string     { return fmt.Sprintf("Index(%s[%s])", i.Array, i.Index) }
+
+// Member is a struct member access: Value.Name or Value->Name.
+type Member struct {
+	Tok   token.Token
+	Value Expr
+	Name  string
+	Arrow bool
+}
+
+func (m *Member) exprNode()          {}
+func (m *Member) Token() token.Token { return m.Tok }
+func (m *Member) String() string {
+	if m.Arrow {
+		return fmt.Sprintf("Member(%s->%s)", m.Value, m.Name)
+	}
+	return fmt.Sprintf("Member(%s.%s)", m.Value, m.Name)
+}
+
+// InitList is a brace enclosed initializer list.
+type InitList struct {
+	Tok    token.Token
+	Values []Expr
+}
+
+func (i *InitList) exprNode()          {}
+func (i *InitList) Token() token.Token { return i.Tok }
+func (i *InitList) String() string {
+	values := make([]string, len(i.Values))
+	for j, v := range i.Values {
+		values[j] = v.String()
+	}
+	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
+}
+
+// Designator selects the member named Field, or the element at
+// Index, of the object being initialized.
+type Designator struct {
+	Field string
+	Index Expr
+}
+
+func (d Designator) String() string {
+	if d.Index != nil {
+		return fmt.Sprintf("[%s]", d.Index)
+	}
+	return "." + d.Field
+}
+
+// Designation is an initializer list entry which names the subobject
+// it initializes, e.g. .x = 1 or [3] = 7.
+type Designation struct {
+	Tok         token.Token
+	Designators []Designator
+	Value       Expr
+}
+
+func (d *Designation) exprNode()          {}
+func (d *Designation) Token() token.Token { return d.Tok }
+func (d *Designation) String() string {
+	var b strings.Builder
+	for _, des := range d.Designators {
+		b.WriteString(des.String())
+	}
+	return fmt.Sprintf("%s = %s", b.String(), d.Value)
+}
+
+// CompoundLit is an unnamed object of type Type initialized by Init.
+type CompoundLit struct {
+	Tok  token.Token
+	Type types.Type
+	Init *InitList
+}
+
+func (c *CompoundLit) exprNode()          {}
+func (c *CompoundLit) Token() token.Token { return c.Tok }
+func (c *CompoundLit) String() string     { return fmt.Sprintf("CompoundLit((%s) %s)", c.Type, c.Init) }
+
+// Call calls the function designated by Func, which is either a
+// function name or an expression with pointer to function type.
 type Call struct {
 	Tok       token.Token
-	Name      string
+	Func      Expr
 	Arguments []Expr
 }
 
@@ -258,5 +588,5 @@ func (c *Call) String() string {
 	for i, a := range c.Arguments {
 		args[i] = a.String()
 	}
-	return fmt.Sprintf("CALL %s (%s)", c.Name, strings.Join(args, ","))
+	return fmt.Sprintf("CALL %s (%s)", c.Func, strings.Join(args, ","))
 }
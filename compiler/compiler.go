@@ -2,10 +2,12 @@ package compiler
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/icholy/cc/ast"
 	"github.com/icholy/cc/parser"
+	"github.com/icholy/cc/types"
 )
 
 func Compile(src string) (string, error) {
@@ -21,56 +23,176 @@ func Compile(src string) (string, error) {
 }
 
 type Compiler struct {
-	asm    *strings.Builder
-	scope  *Scope
-	funcs  map[string]*ast.FuncDec
-	labels int
+	asm      *strings.Builder
+	scope    *Scope
+	funcs    map[string]*ast.FuncDec
+	globals  []*Global
+	labels   int
+	strings  []*String
+	tables   []*JumpTable
+	fn       *ast.FuncDec
+	gotos    map[string]*Label
+	warns    []string
+	linkage  map[string]ast.Storage
+	literals map[*ast.CompoundLit]*Local
+	frame    int
 }
 
 func New() *Compiler {
 	return &Compiler{
-		asm:   &strings.Builder{},
-		funcs: make(map[string]*ast.FuncDec),
+		asm:      &strings.Builder{},
+		funcs:    make(map[string]*ast.FuncDec),
+		scope:    &Scope{Locals: make(map[string]*Local)},
+		linkage:  make(map[string]ast.Storage),
+		literals: make(map[*ast.CompoundLit]*Local),
 	}
 }
 
+// String is a string literal stored in the .rodata section.
+type String struct {
+	Label string
+	Value string
+}
+
+// Local is a name in a Scope. Enumerators are compile-time
+// constants which have a Value instead of storage. The Name of a
+// global is the symbol of the Global it refers to, which differs
+// from the source name for static locals.
 type Local struct {
 	Name     string
+	Type     types.Type
 	Declared bool
 	Offset   int
+	Global   bool
+	Constant bool
+	Value    int
+}
+
+// Operand returns the assembly operand which refers to the variable.
+// Globals are accessed by absolute address.
+func (l *Local) Operand() string {
+	if l.Global {
+		return "_" + l.Name
+	}
+	return fmt.Sprintf("%d(%%ebp)", l.Offset)
+}
+
+// Global is a variable with static storage duration. Data holds the
+// assembler directives for the initial value of defined globals.
+// Globals which are only declared extern are defined in another
+// translation unit and aren't emitted.
+type Global struct {
+	Name      string
+	Type      types.Type
+	Data      []string
+	Defined   bool
+	Tentative bool
+	Static    bool
+}
+
+// Label is a user label. Its name is mapped to an assembly label
+// which is unique across functions.
+type Label struct {
+	Asm  string
+	Used bool
 }
 
+// Loop contains the labels that break and continue jump to. A
+// switch is a Loop without a Continue label.
 type Loop struct {
 	Break, Continue string
 }
 
+// Switch maps the case and default statements of a switch to the
+// labels of the code they select.
+type Switch struct {
+	Labels map[ast.Stmt]string
+}
+
+// JumpTable is a table of case labels stored in the .rodata section.
+type JumpTable struct {
+	Label   string
+	Targets []string
+}
+
 type Scope struct {
 	Parent *Scope
 	Offset int
 	Locals map[string]*Local
 	Loop   *Loop
+	Switch *Switch
 }
 
-func (s *Scope) AddParam(index int, name string) error {
+// AddParam declares a parameter located at offset(%ebp).
+func (s *Scope) AddParam(name string, typ types.Type, offset int) error {
+	if name == "" {
+		return fmt.Errorf("parameter name omitted")
+	}
 	if _, ok := s.Locals[name]; ok {
 		return fmt.Errorf("duplicate parameter name: %s", name)
 	}
 	s.Locals[name] = &Local{
 		Name:     name,
+		Type:     typ,
+		Declared: true,
+		Offset:   offset,
+	}
+	return nil
+}
+
+// DeclareGlobal declares a block scope name which refers to the
+// global with the provided symbol.
+func (s *Scope) DeclareGlobal(name, symbol string, typ types.Type) error {
+	if _, ok := s.Locals[name]; ok {
+		return fmt.Errorf("already declared: %s", name)
+	}
+	s.Locals[name] = &Local{
+		Name:   symbol,
+		Type:   typ,
+		Global: true,
+	}
+	return nil
+}
+
+// DeclareConstant declares an enumerator.
+func (s *Scope) DeclareConstant(name string, value int) error {
+	if _, ok := s.Locals[name]; ok {
+		return fmt.Errorf("already declared: %s", name)
+	}
+	s.Locals[name] = &Local{
+		Name:     name,
+		Type:     types.Int,
 		Declared: true,
-		Offset:   ((index + 1) * 4) + 4,
+		Constant: true,
+		Value:    value,
 	}
 	return nil
 }
 
-func (s *Scope) FindLoop() (*Loop, error) {
-	if s.Loop != nil {
+// FindLoop returns the innermost enclosing loop or switch. Switches
+// are skipped when cont is true since continue doesn't apply to them.
+func (s *Scope) FindLoop(cont bool) (*Loop, error) {
+	if s.Loop != nil && (!cont || s.Loop.Continue != "") {
 		return s.Loop, nil
 	}
 	if s.Parent == nil {
-		return nil, fmt.Errorf("not inside loop")
+		if cont {
+			return nil, fmt.Errorf("continue statement not within a loop")
+		}
+		return nil, fmt.Errorf("break statement not within loop or switch")
+	}
+	return s.Parent.FindLoop(cont)
+}
+
+// FindSwitch returns the innermost enclosing switch.
+func (s *Scope) FindSwitch() (*Switch, error) {
+	if s.Switch != nil {
+		return s.Switch, nil
+	}
+	if s.Parent == nil {
+		return nil, fmt.Errorf("case label not within a switch statement")
 	}
-	return s.Parent.FindLoop()
+	return s.Parent.FindSwitch()
 }
 
 func (s *Scope) DeclaredLocal(name string) (*Local, error) {
@@ -78,7 +200,7 @@ func (s *Scope) DeclaredLocal(name string) (*Local, error) {
 		return loc, nil
 	}
 	if s.Parent != nil {
-		return s.Parent.Local(name)
+		return s.Parent.DeclaredLocal(name)
 	}
 	return nil, fmt.Errorf("undefined: %s", name)
 }
@@ -100,13 +222,16 @@ func (s *Scope) TotalOffset() int {
 	return s.Parent.TotalOffset() + s.Offset
 }
 
-func (s *Scope) Declare(d *ast.VarDec) error {
-	s.Offset -= 4
-	if _, ok := s.Locals[d.Name]; ok {
-		return fmt.Errorf("already declared: %s", d.Name)
+// Declare reserves stack space for a local variable. The size
+// is rounded up to keep the stack 4 byte aligned.
+func (s *Scope) Declare(name string, typ types.Type) error {
+	s.Offset -= (typ.Size() + 3) &^ 3
+	if _, ok := s.Locals[name]; ok {
+		return fmt.Errorf("already declared: %s", name)
 	}
-	s.Locals[d.Name] = &Local{
-		Name:     d.Name,
+	s.Locals[name] = &Local{
+		Name:     name,
+		Type:     typ,
 		Offset:   s.TotalOffset(),
 		Declared: false,
 	}
@@ -142,6 +267,15 @@ func (c *Compiler) Assembly() string {
 	return c.asm.String()
 }
 
+// Warnings returns the diagnostics which didn't prevent compilation.
+func (c *Compiler) Warnings() []string {
+	return c.warns
+}
+
+func (c *Compiler) warnf(format string, args ...interface{}) {
+	c.warns = append(c.warns, fmt.Sprintf(format, args...))
+}
+
 func (c *Compiler) emitf(format string, args ...interface{}) {
 	fmt.Fprintf(c.asm, format+"\n", args...)
 }
@@ -153,118 +287,2054 @@ func (c *Compiler) Compile(p *ast.Program) error {
 			if err := c.funcDec(stmt); err != nil {
 				return err
 			}
+		case *ast.VarDec:
+			if err := c.globalDec(stmt); err != nil {
+				return err
+			}
+		case *ast.TypeDec:
+			if _, err := c.completeType(stmt.Type); err != nil {
+				return err
+			}
+		case *ast.EnumDec:
+			if err := c.enumDec(stmt); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("cannot compile: %s", stmt)
 		}
 	}
+	c.rodata()
+	c.data()
 	return nil
 }
 
-func (c *Compiler) expr(expr ast.Expr) error {
-	switch expr := expr.(type) {
-	case *ast.IntLit:
-		c.emitf("movl $%d, %%eax", expr.Value)
-	case *ast.Null:
-		c.emitf("movl $1, %%eax")
-	case *ast.UnaryOp:
-		return c.unaryOp(expr)
-	case *ast.BinaryOp:
-		return c.binaryOp(expr)
-	case *ast.Var:
-		return c.variable(expr)
-	case *ast.Assign:
-		return c.assign(expr)
-	case *ast.Ternary:
-		return c.ternary(expr)
-	case *ast.Call:
-		return c.call(expr)
-	default:
-		return fmt.Errorf("cannot compile: %s", expr)
+// enumDec declares the enumerators in the current scope.
+func (c *Compiler) enumDec(dec *ast.EnumDec) error {
+	value := 0
+	for _, e := range dec.Enumerators {
+		if e.Value != nil {
+			v, err := c.constant(e.Value)
+			if err != nil {
+				return fmt.Errorf("invalid value for enumerator %s: %v", e.Name, err)
+			}
+			value = v
+		}
+		if _, ok := c.funcs[e.Name]; ok && c.scope.Parent == nil {
+			return fmt.Errorf("redeclared as a different kind of symbol: %s", e.Name)
+		}
+		if err := c.scope.DeclareConstant(e.Name, value); err != nil {
+			return err
+		}
+		value = int(int32(value + 1))
 	}
 	return nil
 }
 
-func (c *Compiler) unaryOp(unary *ast.UnaryOp) error {
-	switch unary.Op {
-	case "-":
-		c.emitf("neg %%eax")
-	case "~":
-		c.emitf("not %%eax")
-	case "!":
-		c.emitf("cmpl $0, %%eax")
-		c.emitf("movl $0, %%eax")
-		c.emitf("sete %%al")
-	default:
-		return fmt.Errorf("invalid unary op: %s", unary)
+func (c *Compiler) globalDec(dec *ast.VarDec) error {
+	if _, ok := c.funcs[dec.Name]; ok {
+		return fmt.Errorf("redeclared as a different kind of symbol: %s", dec.Name)
+	}
+	if loc, ok := c.scope.Locals[dec.Name]; ok && loc.Constant {
+		return fmt.Errorf("redeclared as a different kind of symbol: %s", dec.Name)
+	}
+	var typ types.Type
+	var err error
+	if dec.Storage == ast.Extern && dec.Value == nil {
+		typ, err = c.externType(dec.Name, dec.Type)
+	} else {
+		typ, err = c.objectType(dec.Name, dec.Type, dec.Value)
+	}
+	if err != nil {
+		return err
+	}
+	var data []string
+	if dec.Value != nil {
+		if dec.Storage == ast.Extern {
+			c.warnf("%s: %s initialized and declared extern", dec.Tok.Pos, dec.Name)
+		}
+		if data, err = c.staticInit(typ, dec.Value); err != nil {
+			return fmt.Errorf("invalid initializer for %s: %v", dec.Name, err)
+		}
+	}
+	if err := c.link(dec.Name, dec.Storage, false); err != nil {
+		return err
+	}
+	g := c.global(dec.Name)
+	if g == nil {
+		g = &Global{
+			Name:   dec.Name,
+			Type:   typ,
+			Static: c.linkage[dec.Name] == ast.Static,
+		}
+		c.globals = append(c.globals, g)
+	}
+	if g.Type, err = composite(dec.Name, g.Type, typ); err != nil {
+		return err
+	}
+	if dec.Value != nil {
+		if g.Defined {
+			return fmt.Errorf("redefinition of global: %s", dec.Name)
+		}
+		g.Data = data
+		g.Defined = true
+	}
+	if dec.Storage != ast.Extern {
+		g.Tentative = true
+	}
+	c.scope.Locals[dec.Name] = &Local{
+		Name:     dec.Name,
+		Type:     g.Type,
+		Declared: true,
+		Global:   true,
 	}
 	return nil
 }
 
-func (c *Compiler) stmt(stmt ast.Stmt) error {
-	switch stmt := stmt.(type) {
-	case *ast.Ret:
-		return c.ret(stmt)
-	case *ast.VarDec:
-		return c.varDec(stmt)
-	case *ast.If:
-		return c._if(stmt)
-	case *ast.Block:
-		return c.block(stmt)
-	case *ast.ExprStmt:
-		return c.expr(stmt.Expr)
-	case *ast.While:
-		return c.whileLoop(stmt)
-	case *ast.Do:
-		return c.doLoop(stmt)
-	case *ast.For:
-		return c.forLoop(stmt)
-	case *ast.Break:
-		loop, err := c.scope.FindLoop()
-		if err != nil {
+// link checks the storage class of a file scope declaration against
+// the linkage of the previous declarations of name. Declarations
+// without a storage class have external linkage, except functions
+// which take the linkage of a previous declaration like extern does.
+func (c *Compiler) link(name string, storage ast.Storage, function bool) error {
+	prev, ok := c.linkage[name]
+	switch {
+	case !ok:
+		if storage != ast.Static {
+			storage = ast.Extern
+		}
+		c.linkage[name] = storage
+	case storage == ast.Static && prev != ast.Static:
+		return fmt.Errorf("static declaration of %s follows non-static declaration", name)
+	case storage == ast.Auto && !function && prev == ast.Static:
+		return fmt.Errorf("non-static declaration of %s follows static declaration", name)
+	}
+	return nil
+}
+
+// composite returns the type of a global which was declared with type
+// prev and is redeclared with type t. Arrays declared without a length
+// take it from the other declaration.
+func composite(name string, prev, t types.Type) (types.Type, error) {
+	if types.Identical(prev, t) {
+		return prev, nil
+	}
+	pa, ok1 := prev.(*types.Array)
+	ta, ok2 := t.(*types.Array)
+	if ok1 && ok2 && types.Identical(pa.Elem, ta.Elem) {
+		switch {
+		case pa.Len < 0:
+			return t, nil
+		case ta.Len < 0:
+			return prev, nil
+		}
+	}
+	return nil, fmt.Errorf("conflicting types for %s", name)
+}
+
+// externDec declares a variable with extern storage class at block
+// scope. It refers to the global with the same name, which may be
+// defined in another translation unit.
+func (c *Compiler) externDec(dec *ast.VarDec) error {
+	if dec.Value != nil {
+		return fmt.Errorf("%s has both extern and initializer", dec.Name)
+	}
+	if _, ok := c.funcs[dec.Name]; ok {
+		return fmt.Errorf("redeclared as a different kind of symbol: %s", dec.Name)
+	}
+	typ, err := c.externType(dec.Name, dec.Type)
+	if err != nil {
+		return err
+	}
+	if err := c.link(dec.Name, ast.Extern, false); err != nil {
+		return err
+	}
+	if g := c.global(dec.Name); g != nil {
+		if typ, err = composite(dec.Name, g.Type, typ); err != nil {
 			return err
 		}
-		c.emitf("jmp %s", loop.Break)
-		return nil
-	case *ast.Continue:
-		loop, err := c.scope.FindLoop()
+	} else {
+		c.globals = append(c.globals, &Global{Name: dec.Name, Type: typ})
+	}
+	return c.scope.DeclareGlobal(dec.Name, dec.Name, typ)
+}
+
+// staticDec declares a static local. Its storage is a global with a
+// symbol which is unique to the function.
+func (c *Compiler) staticDec(dec *ast.VarDec) error {
+	typ, err := c.objectType(dec.Name, dec.Type, dec.Value)
+	if err != nil {
+		return err
+	}
+	symbol := c.label(c.fn.Name + "." + dec.Name)
+	c.globals = append(c.globals, &Global{
+		Name:    symbol,
+		Type:    typ,
+		Defined: true,
+		Static:  true,
+	})
+	return c.scope.DeclareGlobal(dec.Name, symbol, typ)
+}
+
+// completeType evaluates the array length expressions in t.
+func (c *Compiler) completeType(t types.Type) (types.Type, error) {
+	switch t := t.(type) {
+	case *types.Qualified:
+		inner, err := c.completeType(t.Type)
+		if err != nil {
+			return nil, err
+		}
+		return types.Qualify(inner, t.Const, t.Volatile), nil
+	case *types.Struct:
+		if t.Defined && !t.LaidOut() {
+			for _, f := range t.Fields {
+				var err error
+				if f.Type, err = c.completeType(f.Type); err != nil {
+					return nil, err
+				}
+			}
+			t.Layout()
+		}
+		return t, nil
+	case *types.Pointer:
+		// structs may refer to themselves through pointers, they're
+		// completed when they're used as objects.
+		if types.IsStruct(t.Elem) {
+			return t, nil
+		}
+		elem, err := c.completeType(t.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return &types.Pointer{Elem: elem}, nil
+	case *types.Array:
+		elem, err := c.completeType(t.Elem)
+		if err != nil {
+			return nil, err
+		}
+		if a, ok := elem.(*types.Array); ok && a.Len < 0 {
+			return nil, fmt.Errorf("array type has incomplete element type: %s", t)
+		}
+		length := t.Len
+		if t.LenExpr != nil {
+			expr, ok := t.LenExpr.(ast.Expr)
+			if !ok {
+				return nil, fmt.Errorf("invalid array length: %s", t.LenExpr)
+			}
+			if length, err = c.constant(expr); err != nil {
+				return nil, fmt.Errorf("invalid array length: %v", err)
+			}
+			if length < 0 {
+				return nil, fmt.Errorf("array length is negative: %s", t)
+			}
+		}
+		return &types.Array{Elem: elem, Len: length}, nil
+	default:
+		if types.IsInteger(t) && t.Size() > 4 {
+			return nil, fmt.Errorf("%s is not supported", t)
+		}
+		return t, nil
+	}
+}
+
+// externType returns the type of a variable declared extern. It is
+// defined elsewhere so the type doesn't have to be complete.
+func (c *Compiler) externType(name string, t types.Type) (types.Type, error) {
+	t, err := c.completeType(t)
+	if err != nil {
+		return nil, err
+	}
+	if t == types.Void {
+		return nil, fmt.Errorf("variable %s declared void", name)
+	}
+	return t, nil
+}
+
+// objectType returns the complete type of a variable. The length of
+// an array declared without one is taken from its initializer.
+func (c *Compiler) objectType(name string, t types.Type, init ast.Expr) (types.Type, error) {
+	t, err := c.completeType(t)
+	if err != nil {
+		return nil, err
+	}
+	if a, ok := t.(*types.Array); ok && a.Len < 0 {
+		if init == nil {
+			return nil, fmt.Errorf("array size missing in %s", name)
+		}
+		if _, t, err = c.initValues(t, init); err != nil {
+			return nil, fmt.Errorf("invalid initializer for %s: %v", name, err)
+		}
+	}
+	if t == types.Void {
+		return nil, fmt.Errorf("variable %s declared void", name)
+	}
+	if !types.IsComplete(t) {
+		return nil, fmt.Errorf("storage size of %s isn't known", name)
+	}
+	return t, nil
+}
+
+// staticInit returns the data directives for a global of type t
+// initialized with init. A nil init is zero initialized.
+func (c *Compiler) staticInit(t types.Type, init ast.Expr) ([]string, error) {
+	t = types.Unqualified(t)
+	if init == nil {
+		return []string{fmt.Sprintf(".zero %d", t.Size())}, nil
+	}
+	values, _, err := c.initValues(t, init)
+	if err != nil {
+		return nil, err
+	}
+	// values which are initialized more than once take the last value.
+	var last []initValue
+	for _, v := range values {
+		end := v.Offset + v.Type.Size()
+		kept := last[:0]
+		for _, prev := range last {
+			if prev.Offset >= end || prev.Offset+prev.Type.Size() <= v.Offset {
+				kept = append(kept, prev)
+			}
+		}
+		last = append(kept, v)
+	}
+	sort.SliceStable(last, func(i, j int) bool {
+		return last[i].Offset < last[j].Offset
+	})
+	var data []string
+	pos := 0
+	for _, v := range last {
+		if v.Offset > pos {
+			data = append(data, fmt.Sprintf(".zero %d", v.Offset-pos))
+		}
+		value, err := c.staticValue(v.Type, v.Value)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, value...)
+		pos = v.Offset + v.Type.Size()
+	}
+	if t.Size() > pos {
+		data = append(data, fmt.Sprintf(".zero %d", t.Size()-pos))
+	}
+	return data, nil
+}
+
+// staticValue returns the data directives for a scalar or struct
+// value of type t. Structs can only be initialized by compound
+// literals.
+func (c *Compiler) staticValue(t types.Type, value ast.Expr) ([]string, error) {
+	if types.IsStruct(t) {
+		lit, ok := value.(*ast.CompoundLit)
+		if !ok {
+			return nil, fmt.Errorf("initializer element is not constant: %s", value)
+		}
+		return c.staticInit(t, lit.Init)
+	}
+	label, err := c.addrConstant(value)
+	if err != nil {
+		return nil, err
+	}
+	if label != "" {
+		return []string{".long " + label}, nil
+	}
+	v, err := c.constant(value)
+	if err != nil {
+		return nil, err
+	}
+	v = truncate(t, v)
+	switch t.Size() {
+	case 1:
+		return []string{fmt.Sprintf(".byte %d", v)}, nil
+	case 2:
+		return []string{fmt.Sprintf(".short %d", v)}, nil
+	default:
+		return []string{fmt.Sprintf(".long %d", v)}, nil
+	}
+}
+
+// truncate converts the constant v to the integer type t.
+func truncate(t types.Type, v int) int {
+	switch {
+	case t == types.Bool:
+		return boolInt(v != 0)
+	case t.Size() == 1 && types.IsUnsigned(t):
+		return int(uint8(v))
+	case t.Size() == 1:
+		return int(int8(v))
+	case t.Size() == 2 && types.IsUnsigned(t):
+		return int(uint16(v))
+	case t.Size() == 2:
+		return int(int16(v))
+	default:
+		return int(int32(v))
+	}
+}
+
+// initValue is a value stored at Offset bytes into an object by its
+// initializer. Type is a scalar or struct type.
+type initValue struct {
+	Offset int
+	Type   types.Type
+	Value  ast.Expr
+}
+
+// initValues flattens the initializer of an object of type t into the
+// values it stores, in the order they're evaluated. Members without a
+// value are zero. The type is returned with the length of an array
+// declared without one taken from the initializer.
+func (c *Compiler) initValues(t types.Type, init ast.Expr) ([]initValue, types.Type, error) {
+	in := &initializer{c: c}
+	if err := in.object(t, 0, init); err != nil {
+		return nil, nil, err
+	}
+	t = types.Unqualified(t)
+	if a, ok := t.(*types.Array); ok && a.Len < 0 {
+		t = &types.Array{Elem: a.Elem, Len: in.length}
+	}
+	return in.values, t, nil
+}
+
+// initializer implements initValues. An array without a length can
+// only be the outermost object, its length is the number of elements
+// which were initialized.
+type initializer struct {
+	c      *Compiler
+	values []initValue
+	length int
+}
+
+// object initializes the object of type t at offset with a single
+// initializer, which is either an expression or a brace enclosed list.
+func (in *initializer) object(t types.Type, offset int, init ast.Expr) error {
+	t = types.Unqualified(t)
+	if list, ok := init.(*ast.InitList); ok {
+		// a string literal may be enclosed in braces.
+		if len(list.Values) == 1 && isString(t, list.Values[0]) {
+			return in.object(t, offset, list.Values[0])
+		}
+		if !isAggregate(t) {
+			if len(list.Values) != 1 {
+				return fmt.Errorf("excess elements in scalar initializer")
+			}
+			if _, ok := list.Values[0].(*ast.Designation); ok {
+				return fmt.Errorf("designator in scalar initializer: %s", list.Values[0])
+			}
+			return in.object(t, offset, list.Values[0])
+		}
+		_, err := in.fill(t, offset, list.Values, true)
+		return err
+	}
+	if isString(t, init) {
+		return in.str(t.(*types.Array), offset, init.(*ast.StringLit))
+	}
+	if types.IsArray(t) {
+		return fmt.Errorf("array must be initialized with a brace enclosed list")
+	}
+	if err := in.c.assignable(t, init); err != nil {
+		return err
+	}
+	in.values = append(in.values, initValue{Offset: offset, Type: t, Value: init})
+	return nil
+}
+
+// str initializes a char array with the characters of a string
+// literal, including the terminating null if there's room for it.
+func (in *initializer) str(a *types.Array, offset int, s *ast.StringLit) error {
+	n := len(s.Value) + 1
+	if a.Len < 0 {
+		in.length = n
+	} else if n-1 > a.Len {
+		return fmt.Errorf("initializer-string for array is too long")
+	} else if n > a.Len {
+		n = a.Len
+	}
+	for i := 0; i < n; i++ {
+		var ch int
+		if i < len(s.Value) {
+			ch = int(s.Value[i])
+		}
+		in.values = append(in.values, initValue{
+			Offset: offset + i,
+			Type:   types.Unqualified(a.Elem),
+			Value:  &ast.CharLit{Tok: s.Tok, Value: ch},
+		})
+	}
+	return nil
+}
+
+// fill initializes the members of the aggregate t at offset from the
+// list of values. When braced is false the braces around the
+// aggregate's initializer were elided, so it stops when all of the
+// members are initialized or at a designator for the enclosing object,
+// and returns the remaining values.
+func (in *initializer) fill(t types.Type, offset int, values []ast.Expr, braced bool) ([]ast.Expr, error) {
+	n := members(t)
+	pos := 0
+	for i := 0; len(values) > 0; i++ {
+		if d, ok := values[0].(*ast.Designation); ok {
+			// only the first value of an elided list can be designated,
+			// when it's the rest of a designation of the enclosing object.
+			if !braced && i > 0 {
+				return values, nil
+			}
+			var err error
+			if pos, err = in.designate(t, d.Designators[0]); err != nil {
+				return nil, err
+			}
+			// the designation replaces the value in the list.
+			next := d.Value
+			if len(d.Designators) > 1 {
+				next = &ast.Designation{Tok: d.Tok, Designators: d.Designators[1:], Value: d.Value}
+			}
+			values = append([]ast.Expr{next}, values[1:]...)
+		}
+		if n >= 0 && pos >= n {
+			if braced {
+				return nil, fmt.Errorf("excess elements in %s initializer", t)
+			}
+			return values, nil
+		}
+		mt, moff := member(t, pos)
+		var err error
+		if values, err = in.member(mt, offset+moff, values); err != nil {
+			return nil, err
+		}
+		pos++
+		if s, ok := t.(*types.Struct); ok && s.Union {
+			// only one member of a union is initialized.
+			pos = n
+		}
+		if a, ok := t.(*types.Array); ok && a.Len < 0 && pos > in.length {
+			in.length = pos
+		}
+	}
+	return nil, nil
+}
+
+// member initializes a member of type t at offset from the next values.
+// It consumes a single value unless the braces around the member's
+// initializer were elided. A designation at the start of values
+// applies to the member.
+func (in *initializer) member(t types.Type, offset int, values []ast.Expr) ([]ast.Expr, error) {
+	t = types.Unqualified(t)
+	v := values[0]
+	if d, ok := v.(*ast.Designation); ok {
+		if !isAggregate(t) {
+			return nil, fmt.Errorf("designator in scalar initializer: %s", d)
+		}
+		return in.fill(t, offset, values, false)
+	}
+	if _, ok := v.(*ast.InitList); ok || !isAggregate(t) || isString(t, v) {
+		return values[1:], in.object(t, offset, v)
+	}
+	if types.IsStruct(t) {
+		// the member may be initialized by a struct value.
+		if vt, err := in.c.typeOf(v); err == nil && types.Identical(t, vt) {
+			return values[1:], in.object(t, offset, v)
+		}
+	}
+	return in.fill(t, offset, values, false)
+}
+
+// designate returns the position of the member selected by d.
+func (in *initializer) designate(t types.Type, d ast.Designator) (int, error) {
+	switch t := t.(type) {
+	case *types.Struct:
+		if d.Index != nil {
+			return 0, fmt.Errorf("array index in non-array initializer: %s", d)
+		}
+		for i, f := range t.Fields {
+			if f.Name == d.Field {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("%s has no member named %s", t, d.Field)
+	case *types.Array:
+		if d.Index == nil {
+			return 0, fmt.Errorf("field name not in record or union initializer: %s", d)
+		}
+		i, err := in.c.constant(d.Index)
+		if err != nil {
+			return 0, fmt.Errorf("nonconstant array index in initializer: %v", err)
+		}
+		if i < 0 || t.Len >= 0 && i >= t.Len {
+			return 0, fmt.Errorf("array index in initializer exceeds array bounds: %s", d)
+		}
+		return i, nil
+	default:
+		return 0, fmt.Errorf("designator in scalar initializer: %s", d)
+	}
+}
+
+// isAggregate reports whether t is an array, struct or union type.
+func isAggregate(t types.Type) bool {
+	return types.IsArray(t) || types.IsStruct(t)
+}
+
+// isString reports whether init is a string literal initializing a
+// char array of type t.
+func isString(t types.Type, init ast.Expr) bool {
+	a, ok := types.Unqualified(t).(*types.Array)
+	if !ok {
+		return false
+	}
+	_, ok = init.(*ast.StringLit)
+	return ok && types.IsInteger(a.Elem) && a.Elem.Size() == 1
+}
+
+// members returns the number of members of an aggregate, or -1 for
+// arrays without a length.
+func members(t types.Type) int {
+	switch t := t.(type) {
+	case *types.Struct:
+		return len(t.Fields)
+	case *types.Array:
+		return t.Len
+	default:
+		return 0
+	}
+}
+
+// member returns the type and offset of the member at position i of
+// an aggregate.
+func member(t types.Type, i int) (types.Type, int) {
+	if s, ok := t.(*types.Struct); ok {
+		return s.Fields[i].Type, s.Fields[i].Offset
+	}
+	a := t.(*types.Array)
+	return a.Elem, i * a.Elem.Size()
+}
+
+// addrConstant returns the symbol of an address constant initializer.
+// An empty string is returned if expr is not an address constant.
+func (c *Compiler) addrConstant(expr ast.Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *ast.StringLit:
+		return c.stringLabel(expr.Value), nil
+	case *ast.UnaryOp:
+		if expr.Op != "&" {
+			return "", nil
+		}
+		if v, ok := expr.Value.(*ast.Var); ok && c.function(v) != nil {
+			return "_" + v.Name, nil
+		}
+		return c.staticObject(expr.Value)
+	case *ast.Index, *ast.Member:
+		// arrays which are elements or members decay to their address.
+		t, err := c.typeOf(expr)
+		if err != nil || !types.IsArray(t) {
+			return "", err
+		}
+		return c.staticObject(expr)
+	case *ast.Var:
+		if loc, err := c.scope.DeclaredLocal(expr.Name); err == nil && loc.Global && types.IsArray(loc.Type) {
+			return loc.Operand(), nil
+		}
+		if c.function(expr) != nil {
+			return "_" + expr.Name, nil
+		}
+		return "", nil
+	case *ast.CompoundLit:
+		if !types.IsArray(expr.Type) {
+			return "", nil
+		}
+		return c.staticLiteral(expr)
+	case *ast.BinaryOp:
+		if expr.Op != "+" && expr.Op != "-" {
+			return "", nil
+		}
+		label, err := c.addrConstant(expr.Left)
+		if err != nil || label == "" {
+			return label, err
+		}
+		t, err := c.typeOf(expr.Left)
+		if err != nil {
+			return "", err
+		}
+		offset, err := c.constant(expr.Right)
+		if err != nil {
+			return "", err
+		}
+		offset *= elemSize(types.Decay(t))
+		if expr.Op == "-" {
+			offset = -offset
+		}
+		return fmt.Sprintf("%s%+d", label, offset), nil
+	default:
+		return "", nil
+	}
+}
+
+// staticObject returns the address of an object with static storage
+// as a symbol plus a constant offset. Elements with a constant index
+// and members are offset from the object which contains them.
+func (c *Compiler) staticObject(expr ast.Expr) (string, error) {
+	label, offset, err := c.objectOffset(expr)
+	if err != nil {
+		return "", err
+	}
+	if offset != 0 {
+		label = fmt.Sprintf("%s%+d", label, offset)
+	}
+	return label, nil
+}
+
+// objectOffset implements staticObject.
+func (c *Compiler) objectOffset(expr ast.Expr) (string, int, error) {
+	switch expr := expr.(type) {
+	case *ast.Var:
+		if loc, err := c.scope.DeclaredLocal(expr.Name); err == nil && loc.Global {
+			return loc.Operand(), 0, nil
+		}
+	case *ast.CompoundLit:
+		label, err := c.staticLiteral(expr)
+		return label, 0, err
+	case *ast.Member:
+		if expr.Arrow {
+			break
+		}
+		f, err := c.field(expr)
+		if err != nil {
+			return "", 0, err
+		}
+		label, offset, err := c.objectOffset(expr.Value)
+		return label, offset + f.Offset, err
+	case *ast.Index:
+		// only arrays are objects, indexing a pointer loads its value.
+		t, err := c.typeOf(expr.Array)
+		if err != nil {
+			return "", 0, err
+		}
+		a, ok := t.(*types.Array)
+		if !ok {
+			break
+		}
+		i, err := c.constant(expr.Index)
+		if err != nil {
+			return "", 0, err
+		}
+		label, offset, err := c.objectOffset(expr.Array)
+		return label, offset + i*a.Elem.Size(), err
+	}
+	return "", 0, fmt.Errorf("not a constant address: %s", expr)
+}
+
+// staticLiteral defines an anonymous global for a compound literal
+// in a static initializer and returns its symbol.
+func (c *Compiler) staticLiteral(lit *ast.CompoundLit) (string, error) {
+	// literals in a function have automatic storage, even when they
+	// initialize a static local.
+	if c.scope.Parent != nil {
+		return "", fmt.Errorf("initializer element is not constant: %s", lit)
+	}
+	t, err := c.objectType("compound literal", lit.Type, lit.Init)
+	if err != nil {
+		return "", err
+	}
+	data, err := c.staticInit(t, lit.Init)
+	if err != nil {
+		return "", err
+	}
+	g := &Global{
+		Name:    c.label("compound_literal"),
+		Type:    t,
+		Data:    data,
+		Defined: true,
+		Static:  true,
+	}
+	c.globals = append(c.globals, g)
+	return "_" + g.Name, nil
+}
+
+func (c *Compiler) global(name string) *Global {
+	for _, g := range c.globals {
+		if g.Name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// data emits the global variables. Initialized globals are placed in the
+// .data section, or .rodata if they're const, and tentative definitions
+// become common symbols. Static globals are local to the object file.
+func (c *Compiler) data() {
+	for _, g := range c.globals {
+		if !g.Defined {
+			if !g.Tentative {
+				continue
+			}
+			if g.Static {
+				c.emitf(".local _%s", g.Name)
+			}
+			c.emitf(".comm _%s,%d", g.Name, g.Type.Size())
+			continue
+		}
+		if types.IsConst(g.Type) {
+			c.emitf(".section .rodata")
+		} else {
+			c.emitf(".data")
+		}
+		if !g.Static {
+			c.emitf(".globl _%s", g.Name)
+		}
+		c.emitf(".align 4")
+		c.emitf("_%s:", g.Name)
+		for _, d := range g.Data {
+			c.emitf("%s", d)
+		}
+	}
+}
+
+// constant evaluates a constant expression.
+func (c *Compiler) constant(expr ast.Expr) (int, error) {
+	switch expr := expr.(type) {
+	case *ast.IntLit:
+		if expr.Type.Size() > 4 {
+			return 0, fmt.Errorf("%s integer constants are not supported: %s", expr.Type, expr.Tok.Text)
+		}
+		return int(int32(expr.Value)), nil
+	case *ast.CharLit:
+		return expr.Value, nil
+	case *ast.Var:
+		loc, err := c.scope.DeclaredLocal(expr.Name)
+		if err != nil {
+			return 0, err
+		}
+		if loc.Constant {
+			return loc.Value, nil
+		}
+	case *ast.UnaryOp:
+		v, err := c.constant(expr.Value)
+		if err != nil {
+			return 0, err
+		}
+		switch expr.Op {
+		case "-":
+			return int(int32(-v)), nil
+		case "~":
+			return ^v, nil
+		case "!":
+			return boolInt(v == 0), nil
+		}
+	case *ast.BinaryOp:
+		left, err := c.constant(expr.Left)
+		if err != nil {
+			return 0, err
+		}
+		right, err := c.constant(expr.Right)
+		if err != nil {
+			return 0, err
+		}
+		lt, rt, err := c.operandTypes(expr)
+		if err != nil {
+			return 0, err
+		}
+		return constantOp(expr.Op, left, right, types.IsUnsigned(commonType(expr.Op, lt, rt)))
+	case *ast.Cast:
+		v, err := c.constant(expr.Value)
+		if err != nil {
+			return 0, err
+		}
+		if !types.IsInteger(expr.Type) {
+			break
+		}
+		return truncate(expr.Type, v), nil
+	case *ast.SizeOf:
+		return c.sizeOf(expr)
+	case *ast.Ternary:
+		cond, err := c.constant(expr.Condition)
+		if err != nil {
+			return 0, err
+		}
+		if cond != 0 {
+			return c.constant(expr.Then)
+		}
+		return c.constant(expr.Else)
+	}
+	return 0, fmt.Errorf("not a constant expression: %s", expr)
+}
+
+// constantOp applies a binary operator to constant operands. The
+// division, right shift and relational operators treat the operands
+// as 32 bit unsigned integers when unsigned is true.
+func constantOp(op string, left, right int, unsigned bool) (int, error) {
+	if unsigned {
+		switch op {
+		case "/", "%", ">>", "<", "<=", ">", ">=":
+			return unsignedOp(op, uint32(left), uint32(right))
+		}
+	}
+	var v int
+	switch op {
+	case "+":
+		v = left + right
+	case "-":
+		v = left - right
+	case "*":
+		v = left * right
+	case "/", "%":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero in constant expression")
+		}
+		if op == "/" {
+			v = left / right
+		} else {
+			v = left % right
+		}
+	case "&":
+		v = left & right
+	case "|":
+		v = left | right
+	case "^":
+		v = left ^ right
+	case "<<":
+		v = left << uint(right&31)
+	case ">>":
+		v = left >> uint(right&31)
+	case "==":
+		v = boolInt(left == right)
+	case "!=":
+		v = boolInt(left != right)
+	case "<":
+		v = boolInt(left < right)
+	case "<=":
+		v = boolInt(left <= right)
+	case ">":
+		v = boolInt(left > right)
+	case ">=":
+		v = boolInt(left >= right)
+	case "&&":
+		v = boolInt(left != 0 && right != 0)
+	case "||":
+		v = boolInt(left != 0 || right != 0)
+	default:
+		return 0, fmt.Errorf("invalid operator in constant expression: %s", op)
+	}
+	return int(int32(v)), nil
+}
+
+func unsignedOp(op string, left, right uint32) (int, error) {
+	var v uint32
+	switch op {
+	case "/", "%":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero in constant expression")
+		}
+		if op == "/" {
+			v = left / right
+		} else {
+			v = left % right
+		}
+	case ">>":
+		v = left >> (right & 31)
+	case "<":
+		return boolInt(left < right), nil
+	case "<=":
+		return boolInt(left <= right), nil
+	case ">":
+		return boolInt(left > right), nil
+	default:
+		return boolInt(left >= right), nil
+	}
+	return int(int32(v)), nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// stringLabel returns the label of the string literal with the provided
+// value. Each distinct value is only stored once.
+func (c *Compiler) stringLabel(value string) string {
+	for _, s := range c.strings {
+		if s.Value == value {
+			return s.Label
+		}
+	}
+	s := &String{Label: c.label("str"), Value: value}
+	c.strings = append(c.strings, s)
+	return s.Label
+}
+
+func (c *Compiler) rodata() {
+	if len(c.strings) == 0 && len(c.tables) == 0 {
+		return
+	}
+	c.emitf(".section .rodata")
+	for _, s := range c.strings {
+		c.emitf("%s:", s.Label)
+		c.emitf(".asciz \"%s\"", escapeString(s.Value))
+	}
+	for _, t := range c.tables {
+		c.emitf(".align 4")
+		c.emitf("%s:", t.Label)
+		for _, target := range t.Targets {
+			c.emitf(".long %s", target)
+		}
+	}
+}
+
+// escapeString escapes a string for use in an assembler string directive.
+func escapeString(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch == '"' || ch == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		case ch < ' ' || ch > '~':
+			fmt.Fprintf(&b, "\\%03o", ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+func (c *Compiler) expr(expr ast.Expr) error {
+	switch expr := expr.(type) {
+	case *ast.IntLit:
+		if expr.Type.Size() > 4 {
+			return fmt.Errorf("%s integer constants are not supported: %s", expr.Type, expr.Tok.Text)
+		}
+		c.emitf("movl $%d, %%eax", expr.Value)
+	case *ast.CharLit:
+		c.emitf("movl $%d, %%eax", expr.Value)
+	case *ast.StringLit:
+		c.emitf("movl $%s, %%eax", c.stringLabel(expr.Value))
+	case *ast.Null:
+		c.emitf("movl $1, %%eax")
+	case *ast.UnaryOp:
+		return c.unaryOp(expr)
+	case *ast.BinaryOp:
+		return c.binaryOp(expr)
+	case *ast.Var:
+		return c.variable(expr)
+	case *ast.Assign:
+		return c.assign(expr)
+	case *ast.CompoundAssign:
+		return c.compoundAssign(expr)
+	case *ast.IncDec:
+		return c.incDec(expr)
+	case *ast.Index:
+		return c.expr(index(expr))
+	case *ast.Member:
+		return c.member(expr)
+	case *ast.Cast:
+		return c.cast(expr)
+	case *ast.Ternary:
+		return c.ternary(expr)
+	case *ast.Comma:
+		if err := c.discard(expr.Left); err != nil {
+			return err
+		}
+		return c.expr(expr.Right)
+	case *ast.SizeOf:
+		size, err := c.sizeOf(expr)
+		if err != nil {
+			return err
+		}
+		c.emitf("movl $%d, %%eax", size)
+	case *ast.Call:
+		t, err := c.typeOf(expr)
+		if err != nil {
+			return err
+		}
+		if t == types.Void {
+			return fmt.Errorf("void value not ignored as it ought to be: %s", expr)
+		}
+		return c.call(expr)
+	case *ast.VaArg:
+		return c.vaArg(expr)
+	case *ast.VaStart, *ast.VaEnd:
+		return fmt.Errorf("void value not ignored as it ought to be: %s", expr)
+	case *ast.CompoundLit:
+		loc, err := c.compoundLit(expr)
+		if err != nil {
+			return err
+		}
+		c.load(loc.Type, loc.Operand(), "%eax")
+	default:
+		return fmt.Errorf("cannot compile: %s", expr)
+	}
+	return nil
+}
+
+// discard evaluates an expression whose value isn't used. Calls to
+// void functions and casts to void are only allowed here.
+func (c *Compiler) discard(expr ast.Expr) error {
+	switch expr := expr.(type) {
+	case *ast.Call:
+		return c.call(expr)
+	case *ast.VaStart:
+		return c.vaStart(expr)
+	case *ast.VaEnd:
+		// there's nothing to clean up, only the argument is checked.
+		return c.vaList(expr.List)
+	case *ast.Cast:
+		if expr.Type == types.Void {
+			return c.discard(expr.Value)
+		}
+	case *ast.Comma:
+		if err := c.discard(expr.Left); err != nil {
+			return err
+		}
+		return c.discard(expr.Right)
+	}
+	return c.expr(expr)
+}
+
+// typeOf returns the unqualified type of an expression.
+func (c *Compiler) typeOf(expr ast.Expr) (types.Type, error) {
+	t, err := c.qualifiedType(expr)
+	if err != nil {
+		return nil, err
+	}
+	return types.Unqualified(t), nil
+}
+
+// qualifiedType returns the type of an expression including the
+// qualifiers of the object it designates.
+func (c *Compiler) qualifiedType(expr ast.Expr) (types.Type, error) {
+	switch expr := expr.(type) {
+	case *ast.IntLit:
+		return expr.Type, nil
+	case *ast.CharLit, *ast.Null:
+		return types.Int, nil
+	case *ast.StringLit:
+		return &types.Pointer{Elem: types.Char}, nil
+	case *ast.Var:
+		if fn := c.function(expr); fn != nil {
+			return fn.Type, nil
+		}
+		loc, err := c.scope.DeclaredLocal(expr.Name)
+		if err != nil {
+			return nil, err
+		}
+		return loc.Type, nil
+	case *ast.UnaryOp:
+		if expr.Op == "&" {
+			t, err := c.qualifiedType(expr.Value)
+			if err != nil {
+				return nil, err
+			}
+			return &types.Pointer{Elem: t}, nil
+		}
+		t, err := c.typeOf(expr.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch expr.Op {
+		case "*":
+			p, ok := types.Decay(t).(*types.Pointer)
+			if !ok {
+				return nil, fmt.Errorf("invalid indirection of %s: %s", t, expr.Value)
+			}
+			return p.Elem, nil
+		case "!":
+			return types.Int, nil
+		default:
+			return types.Promote(t), nil
+		}
+	case *ast.BinaryOp:
+		left, right, err := c.operandTypes(expr)
+		if err != nil {
+			return nil, err
+		}
+		switch expr.Op {
+		case "+":
+			if types.IsPointer(right) {
+				return right, nil
+			}
+			if types.IsPointer(left) {
+				return left, nil
+			}
+		case "-":
+			if types.IsPointer(left) && !types.IsPointer(right) {
+				return left, nil
+			}
+			if types.IsPointer(left) {
+				return types.Int, nil
+			}
+		case "&&", "||":
+			return types.Int, nil
+		}
+		if isComparison(expr.Op) {
+			return types.Int, nil
+		}
+		return commonType(expr.Op, left, right), nil
+	case *ast.Index:
+		return c.qualifiedType(index(expr))
+	case *ast.Member:
+		f, err := c.field(expr)
+		if err != nil {
+			return nil, err
+		}
+		// the members of a const struct are const.
+		obj, err := c.qualifiedType(expr.Value)
+		if err != nil {
+			return nil, err
+		}
+		if expr.Arrow {
+			obj = types.Decay(obj).(*types.Pointer).Elem
+		}
+		if types.IsConst(obj) {
+			return types.Qualify(f.Type, true, false), nil
+		}
+		return f.Type, nil
+	case *ast.Cast:
+		return c.completeType(expr.Type)
+	case *ast.Assign:
+		return c.typeOf(expr.Target)
+	case *ast.CompoundAssign:
+		return c.typeOf(expr.Target)
+	case *ast.IncDec:
+		return c.typeOf(expr.Target)
+	case *ast.Comma:
+		return c.typeOf(expr.Right)
+	case *ast.SizeOf:
+		return types.UInt, nil
+	case *ast.Ternary:
+		then, err := c.typeOf(expr.Then)
+		if err != nil {
+			return nil, err
+		}
+		els, err := c.typeOf(expr.Else)
+		if err != nil {
+			return nil, err
+		}
+		if types.IsInteger(then) && types.IsInteger(els) {
+			return types.Arith(then, els), nil
+		}
+		return then, nil
+	case *ast.Call:
+		fn, err := c.funcType(expr)
+		if err != nil {
+			return nil, err
+		}
+		return fn.Result, nil
+	case *ast.VaArg:
+		return c.completeType(expr.Type)
+	case *ast.VaStart, *ast.VaEnd:
+		return types.Void, nil
+	case *ast.CompoundLit:
+		if loc, ok := c.literals[expr]; ok {
+			return loc.Type, nil
+		}
+		return c.objectType("compound literal", expr.Type, expr.Init)
+	default:
+		return nil, fmt.Errorf("cannot determine type: %s", expr)
+	}
+}
+
+// sizeOf returns the size in bytes of the type named by a sizeof
+// expression. The operand expression is never evaluated.
+func (c *Compiler) sizeOf(s *ast.SizeOf) (int, error) {
+	if str, ok := s.Value.(*ast.StringLit); ok {
+		return len(str.Value) + 1, nil
+	}
+	t := s.Type
+	if t == nil {
+		var err error
+		if t, err = c.typeOf(s.Value); err != nil {
+			return 0, err
+		}
+	}
+	t, err := c.completeType(t)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := t.(*types.Func); ok {
+		return 0, fmt.Errorf("invalid application of sizeof to a function type: %s", s)
+	}
+	if !types.IsComplete(t) {
+		return 0, fmt.Errorf("invalid application of sizeof to incomplete type %s", t)
+	}
+	return t.Size(), nil
+}
+
+// commonType returns the type which the operands of a binary
+// operator are converted to. The result of a shift has the type of
+// its promoted left operand and pointers are compared as unsigned
+// addresses.
+func commonType(op string, left, right types.Type) types.Type {
+	if op == "<<" || op == ">>" {
+		return types.Promote(left)
+	}
+	if types.IsPointer(left) || types.IsPointer(right) {
+		return types.UInt
+	}
+	return types.Arith(left, right)
+}
+
+// operandTypes returns the types of a binary operation's operands
+// after array to pointer conversion.
+func (c *Compiler) operandTypes(binary *ast.BinaryOp) (types.Type, types.Type, error) {
+	left, err := c.typeOf(binary.Left)
+	if err != nil {
+		return nil, nil, err
+	}
+	right, err := c.typeOf(binary.Right)
+	if err != nil {
+		return nil, nil, err
+	}
+	return types.Decay(left), types.Decay(right), nil
+}
+
+// field returns the struct field selected by a member expression.
+func (c *Compiler) field(m *ast.Member) (*types.Field, error) {
+	t, err := c.typeOf(m.Value)
+	if err != nil {
+		return nil, err
+	}
+	if m.Arrow {
+		p, ok := types.Decay(t).(*types.Pointer)
+		if !ok {
+			return nil, fmt.Errorf("invalid type argument of ->: %s", m.Value)
+		}
+		t = types.Unqualified(p.Elem)
+	}
+	s, ok := t.(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("request for member %s in something not a struct or union: %s", m.Name, m.Value)
+	}
+	if !s.Defined {
+		return nil, fmt.Errorf("dereferencing pointer to incomplete type %s", s)
+	}
+	if _, err := c.completeType(s); err != nil {
+		return nil, err
+	}
+	f := s.Field(m.Name)
+	if f == nil {
+		return nil, fmt.Errorf("%s has no member named %s", s, m.Name)
+	}
+	return f, nil
+}
+
+// assignable checks that a value can be assigned to an object of type t.
+// Integers and pointers only convert to each other for the null pointer
+// constant, and pointers must point to the same type unless one of them
+// is a void pointer. The qualifiers of the type pointed to can be added
+// but not removed.
+func (c *Compiler) assignable(t types.Type, value ast.Expr) error {
+	vt, err := c.typeOf(value)
+	if err != nil {
+		return err
+	}
+	t, vt = types.Unqualified(t), types.Decay(vt)
+	incompatible := fmt.Errorf("incompatible types when assigning to type %s from type %s", t, vt)
+	switch {
+	case types.IsStruct(t) || types.IsStruct(vt):
+		if !types.Identical(t, vt) {
+			return incompatible
+		}
+	case types.IsPointer(t) && types.IsInteger(vt):
+		if v, err := c.constant(value); err != nil || v != 0 {
+			return fmt.Errorf("making pointer from integer without a cast: %s", value)
+		}
+	case types.IsInteger(t) && types.IsPointer(vt):
+		// any pointer can be converted to _Bool.
+		if t != types.Bool {
+			return fmt.Errorf("making integer from pointer without a cast: %s", value)
+		}
+	case types.IsPointer(t) && types.IsPointer(vt):
+		elem, velem := t.(*types.Pointer).Elem, vt.(*types.Pointer).Elem
+		if types.IsConst(velem) && !types.IsConst(elem) || types.IsVolatile(velem) && !types.IsVolatile(elem) {
+			return fmt.Errorf("assignment to %s discards qualifiers from pointer target type %s", t, vt)
+		}
+		elem, velem = types.Unqualified(elem), types.Unqualified(velem)
+		if elem != types.Void && velem != types.Void && !types.Identical(elem, velem) {
+			return incompatible
+		}
+	}
+	return nil
+}
+
+// index returns the expression *(a + i) which is equivalent to a[i].
+func index(expr *ast.Index) ast.Expr {
+	return &ast.UnaryOp{
+		Tok: expr.Tok,
+		Op:  "*",
+		Value: &ast.BinaryOp{
+			Tok:   expr.Tok,
+			Op:    "+",
+			Left:  expr.Array,
+			Right: expr.Index,
+		},
+	}
+}
+
+// elemSize returns the size of the type t points to. Pointer arithmetic
+// is scaled by this amount.
+func elemSize(t types.Type) int {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem.Size()
+	}
+	return 1
+}
+
+// load moves a value of type t from src into the register dst.
+// Arrays are converted to the address of their first element and
+// structs and functions are represented by their address.
+func (c *Compiler) load(t types.Type, src, dst string) {
+	if _, ok := t.(*types.Func); ok || types.IsArray(t) || types.IsStruct(t) {
+		c.emitf("leal %s, %s", src, dst)
+	} else {
+		c.emitf("%s %s, %s", extend(t), src, dst)
+	}
+}
+
+// extend returns the instruction which loads a value of type t into
+// a 32 bit register. Narrow types are sign or zero extended.
+func extend(t types.Type) string {
+	switch t.Size() {
+	case 1:
+		return setcc("movsbl", "movzbl", types.IsUnsigned(t))
+	case 2:
+		return setcc("movswl", "movzwl", types.IsUnsigned(t))
+	default:
+		return "movl"
+	}
+}
+
+// convert converts the value in %eax to the type t. Values of narrow
+// integer types are truncated and extended back to 32 bits and values
+// converted to _Bool become 0 or 1.
+func (c *Compiler) convert(t types.Type) {
+	switch {
+	case t == types.Bool:
+		c.emitf("cmpl $0, %%eax")
+		c.emitf("movl $0, %%eax")
+		c.emitf("setne %%al")
+	case !types.IsInteger(t):
+	case t.Size() == 1:
+		c.emitf("%s %%al, %%eax", extend(t))
+	case t.Size() == 2:
+		c.emitf("%s %%ax, %%eax", extend(t))
+	}
+}
+
+// store moves a value of type t from %eax into dst. Structs are
+// copied from the address in %eax.
+func (c *Compiler) store(t types.Type, dst string) {
+	if types.IsStruct(t) {
+		c.emitf("leal %s, %%ecx", dst)
+		c.copy("%eax", "%ecx", t.Size())
+	} else if t.Size() == 1 {
+		c.emitf("movb %%al, %s", dst)
+	} else if t.Size() == 2 {
+		c.emitf("movw %%ax, %s", dst)
+	} else {
+		c.emitf("movl %%eax, %s", dst)
+	}
+}
+
+// copy copies size bytes from the address in src to the address in dst
+// using %edx as scratch.
+func (c *Compiler) copy(src, dst string, size int) {
+	for off := 0; off < size; {
+		if size-off >= 4 {
+			c.emitf("movl %d(%s), %%edx", off, src)
+			c.emitf("movl %%edx, %d(%s)", off, dst)
+			off += 4
+		} else {
+			c.emitf("movb %d(%s), %%dl", off, src)
+			c.emitf("movb %%dl, %d(%s)", off, dst)
+			off++
+		}
+	}
+}
+
+// function returns the function named by v, or nil if v refers to
+// a variable or isn't declared.
+func (c *Compiler) function(v *ast.Var) *ast.FuncDec {
+	if _, err := c.scope.DeclaredLocal(v.Name); err == nil {
+		return nil
+	}
+	return c.funcs[v.Name]
+}
+
+// funcType returns the type of the function called by call.
+func (c *Compiler) funcType(call *ast.Call) (*types.Func, error) {
+	if v, ok := call.Func.(*ast.Var); ok && c.function(v) == nil {
+		if _, err := c.scope.DeclaredLocal(v.Name); err != nil {
+			return nil, fmt.Errorf("undefined function: %s", v.Name)
+		}
+	}
+	t, err := c.typeOf(call.Func)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := types.Decay(t).(*types.Pointer); ok {
+		if fn, ok := p.Elem.(*types.Func); ok {
+			return fn, nil
+		}
+	}
+	return nil, fmt.Errorf("called object is not a function or function pointer: %s", call.Func)
+}
+
+// object returns the variable referred to by v. Enumerators are not
+// objects since they have no storage.
+func (c *Compiler) object(v *ast.Var) (*Local, error) {
+	loc, err := c.scope.DeclaredLocal(v.Name)
+	if err != nil {
+		return nil, err
+	}
+	if loc.Constant {
+		return nil, fmt.Errorf("enumerator %s is not an lvalue", v.Name)
+	}
+	return loc, nil
+}
+
+// addr evaluates the address of an lvalue into %eax.
+func (c *Compiler) addr(expr ast.Expr) error {
+	switch expr := expr.(type) {
+	case *ast.Var:
+		if fn := c.function(expr); fn != nil {
+			c.emitf("movl $_%s, %%eax", fn.Name)
+			return nil
+		}
+		loc, err := c.object(expr)
+		if err != nil {
+			return err
+		}
+		c.emitf("leal %s, %%eax", loc.Operand())
+		return nil
+	case *ast.UnaryOp:
+		if expr.Op == "*" {
+			return c.expr(expr.Value)
+		}
+	case *ast.Index:
+		return c.addr(index(expr))
+	case *ast.CompoundLit:
+		loc, err := c.compoundLit(expr)
+		if err != nil {
+			return err
+		}
+		c.emitf("leal %s, %%eax", loc.Operand())
+		return nil
+	case *ast.Member:
+		f, err := c.field(expr)
+		if err != nil {
+			return err
+		}
+		if expr.Arrow {
+			err = c.expr(expr.Value)
+		} else {
+			err = c.addr(expr.Value)
+		}
+		if err != nil {
+			return err
+		}
+		if f.Offset != 0 {
+			c.emitf("addl $%d, %%eax", f.Offset)
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot take address of: %s", expr)
+}
+
+// compoundLit initializes the storage of a compound literal, which
+// is allocated by allocateLiterals, each time it's evaluated.
+func (c *Compiler) compoundLit(lit *ast.CompoundLit) (*Local, error) {
+	loc, ok := c.literals[lit]
+	if !ok {
+		return nil, fmt.Errorf("compound literal is not constant: %s", lit)
+	}
+	if err := c.initLocal(loc.Type, lit.Init, loc.Offset); err != nil {
+		return nil, fmt.Errorf("invalid initializer for compound literal: %v", err)
+	}
+	return loc, nil
+}
+
+// indirect is the operand returned by lvalue for objects which are
+// accessed through a pointer.
+const indirect = "(%ecx)"
+
+// lvalue returns an operand which refers to the object designated by
+// expr. Variables are referred to directly, other objects have their
+// address computed into %ecx. The value in %eax is preserved.
+func (c *Compiler) lvalue(expr ast.Expr) (string, error) {
+	t, err := c.qualifiedType(expr)
+	if err != nil {
+		return "", err
+	}
+	if types.IsConst(t) {
+		if _, ok := expr.(*ast.Var); ok {
+			return "", fmt.Errorf("assignment of read-only variable: %s", expr)
+		}
+		return "", fmt.Errorf("assignment of read-only location: %s", expr)
+	}
+	if v, ok := expr.(*ast.Var); ok {
+		loc, err := c.object(v)
+		if err != nil {
+			return "", err
+		}
+		return loc.Operand(), nil
+	}
+	c.emitf("pushl %%eax")
+	if err := c.addr(expr); err != nil {
+		return "", err
+	}
+	c.emitf("movl %%eax, %%ecx")
+	c.emitf("pop %%eax")
+	return indirect, nil
+}
+
+func (c *Compiler) member(m *ast.Member) error {
+	f, err := c.field(m)
+	if err != nil {
+		return err
+	}
+	if err := c.addr(m); err != nil {
+		return err
+	}
+	c.load(f.Type, "(%eax)", "%eax")
+	return nil
+}
+
+func (c *Compiler) cast(cast *ast.Cast) error {
+	t, err := c.completeType(cast.Type)
+	if err != nil {
+		return err
+	}
+	t = types.Unqualified(t)
+	vt, err := c.typeOf(cast.Value)
+	if err != nil {
+		return err
+	}
+	if t == types.Void {
+		return fmt.Errorf("void value not ignored as it ought to be: %s", cast)
+	}
+	if types.IsStruct(t) || types.IsArray(t) || types.IsStruct(vt) {
+		return fmt.Errorf("invalid cast from %s to %s", vt, t)
+	}
+	if err := c.expr(cast.Value); err != nil {
+		return err
+	}
+	c.convert(t)
+	return nil
+}
+
+func (c *Compiler) unaryOp(unary *ast.UnaryOp) error {
+	switch unary.Op {
+	case "&":
+		return c.addr(unary.Value)
+	case "*":
+		t, err := c.typeOf(unary)
+		if err != nil {
+			return err
+		}
+		if t == types.Void {
+			return fmt.Errorf("dereferencing void pointer: %s", unary)
+		}
+		if err := c.expr(unary.Value); err != nil {
+			return err
+		}
+		c.load(t, "(%eax)", "%eax")
+		return nil
+	}
+	if err := c.expr(unary.Value); err != nil {
+		return err
+	}
+	switch unary.Op {
+	case "-":
+		c.emitf("neg %%eax")
+	case "~":
+		c.emitf("not %%eax")
+	case "!":
+		c.emitf("cmpl $0, %%eax")
+		c.emitf("movl $0, %%eax")
+		c.emitf("sete %%al")
+	default:
+		return fmt.Errorf("invalid unary op: %s", unary)
+	}
+	return nil
+}
+
+func (c *Compiler) stmt(stmt ast.Stmt) error {
+	switch stmt := stmt.(type) {
+	case *ast.Ret:
+		return c.ret(stmt)
+	case *ast.VarDec:
+		return c.varDec(stmt)
+	case *ast.TypeDec:
+		_, err := c.completeType(stmt.Type)
+		return err
+	case *ast.EnumDec:
+		c.declareEnumerators(stmt, true)
+		return nil
+	case *ast.If:
+		return c._if(stmt)
+	case *ast.Block:
+		return c.block(stmt)
+	case *ast.ExprStmt:
+		return c.discard(stmt.Expr)
+	case *ast.While:
+		return c.whileLoop(stmt)
+	case *ast.Do:
+		return c.doLoop(stmt)
+	case *ast.For:
+		return c.forLoop(stmt)
+	case *ast.Switch:
+		return c._switch(stmt)
+	case *ast.Case:
+		return c.caseLabel(stmt, stmt.Body)
+	case *ast.Default:
+		return c.caseLabel(stmt, stmt.Body)
+	case *ast.Label:
+		c.emitf("%s:", c.gotos[stmt.Name].Asm)
+		return c.stmt(stmt.Body)
+	case *ast.Goto:
+		label, ok := c.gotos[stmt.Label]
+		if !ok {
+			return fmt.Errorf("label %s used but not defined", stmt.Label)
+		}
+		label.Used = true
+		c.emitf("jmp %s", label.Asm)
+		return nil
+	case *ast.Break:
+		loop, err := c.scope.FindLoop(false)
+		if err != nil {
+			return err
+		}
+		c.emitf("jmp %s", loop.Break)
+		return nil
+	case *ast.Continue:
+		loop, err := c.scope.FindLoop(true)
+		if err != nil {
+			return err
+		}
+		c.emitf("jmp %s", loop.Continue)
+		return nil
+	default:
+		return fmt.Errorf("cannot compile: %s", stmt)
+	}
+}
+
+func (c *Compiler) forLoop(f *ast.For) error {
+	for _, stmt := range f.Setup {
+		if dec, ok := stmt.(*ast.VarDec); ok && dec.Storage != ast.Auto {
+			return fmt.Errorf("declaration of non-auto variable %s in for loop initial declaration", dec.Name)
+		}
+	}
+	loop := c.enterLoopScope()
+	if err := c.allocate(f.Setup...); err != nil {
+		return err
+	}
+	skipInc := c.label("for_skip_inc")
+	for _, stmt := range f.Setup {
+		if err := c.stmt(stmt); err != nil {
+			return err
+		}
+	}
+	c.emitf("jmp %s", skipInc)
+	c.emitf("%s:", loop.Continue)
+	if err := c.discard(f.Increment); err != nil {
+		return err
+	}
+	c.emitf("%s:", skipInc)
+	if err := c.expr(f.Condition); err != nil {
+		return err
+	}
+	c.emitf("cmpl $0, %%eax")
+	c.emitf("je %s", loop.Break)
+	if err := c.stmt(f.Body); err != nil {
+		return err
+	}
+	c.emitf("jmp %s", loop.Continue)
+	c.emitf("%s:", loop.Break)
+	c.leaveScope()
+	return nil
+}
+
+// _switch compiles a switch statement. The cases are collected from
+// the body up front so that the dispatch code can be emitted before
+// it.
+func (c *Compiler) _switch(s *ast.Switch) error {
+	t, err := c.typeOf(s.Value)
+	if err != nil {
+		return err
+	}
+	if !types.IsInteger(t) {
+		return fmt.Errorf("switch quantity not an integer: %s", s.Value)
+	}
+	t = types.Promote(t)
+	sw := &Switch{Labels: make(map[ast.Stmt]string)}
+	var (
+		cases  []int
+		labels = make(map[int]string)
+		brk    = c.label("switch_break")
+		deflt  = brk
+	)
+	for _, stmt := range switchCases(s.Body) {
+		label := c.label("case")
+		sw.Labels[stmt] = label
+		cs, ok := stmt.(*ast.Case)
+		if !ok {
+			if deflt != brk {
+				return fmt.Errorf("multiple default labels in one switch")
+			}
+			deflt = label
+			continue
+		}
+		v, err := c.constant(cs.Value)
+		if err != nil {
+			return fmt.Errorf("invalid case label: %v", err)
+		}
+		v = truncate(t, v)
+		if _, ok := labels[v]; ok {
+			return fmt.Errorf("duplicate case value: %s", cs.Value)
+		}
+		labels[v] = label
+		cases = append(cases, v)
+	}
+	sort.Ints(cases)
+	if err := c.expr(s.Value); err != nil {
+		return err
+	}
+	c.enterScope()
+	c.scope.Loop = &Loop{Break: brk}
+	c.scope.Switch = sw
+	if n := len(cases); n >= 4 && int64(cases[n-1])-int64(cases[0]) < int64(3*n) {
+		c.jumpTable(cases, labels, deflt)
+	} else {
+		for _, v := range cases {
+			c.emitf("cmpl $%d, %%eax", v)
+			c.emitf("je %s", labels[v])
+		}
+		c.emitf("jmp %s", deflt)
+	}
+	if err := c.stmt(s.Body); err != nil {
+		return err
+	}
+	c.emitf("%s:", brk)
+	c.leaveScope()
+	return nil
+}
+
+// jumpTable dispatches on the value in %eax using a table indexed by
+// its distance from the smallest case. Values outside of the table
+// wrap around to large unsigned numbers and go to deflt.
+func (c *Compiler) jumpTable(cases []int, labels map[int]string, deflt string) {
+	min, max := cases[0], cases[len(cases)-1]
+	table := &JumpTable{Label: c.label("switch_table")}
+	for v := min; v <= max; v++ {
+		if label, ok := labels[v]; ok {
+			table.Targets = append(table.Targets, label)
+		} else {
+			table.Targets = append(table.Targets, deflt)
+		}
+	}
+	c.tables = append(c.tables, table)
+	if min != 0 {
+		c.emitf("subl $%d, %%eax", min)
+	}
+	c.emitf("cmpl $%d, %%eax", max-min)
+	c.emitf("ja %s", deflt)
+	c.emitf("jmp *%s(,%%eax,4)", table.Label)
+}
+
+// switchCases returns the case and default statements which belong
+// to a switch with the given body. Cases of nested switches belong
+// to those switches instead.
+func switchCases(stmt ast.Stmt) []ast.Stmt {
+	switch stmt := stmt.(type) {
+	case *ast.Case:
+		return append([]ast.Stmt{stmt}, switchCases(stmt.Body)...)
+	case *ast.Default:
+		return append([]ast.Stmt{stmt}, switchCases(stmt.Body)...)
+	case *ast.Block:
+		var cases []ast.Stmt
+		for _, s := range stmt.Statements {
+			cases = append(cases, switchCases(s)...)
+		}
+		return cases
+	case *ast.If:
+		return append(switchCases(stmt.Then), switchCases(stmt.Else)...)
+	case *ast.While:
+		return switchCases(stmt.Body)
+	case *ast.Do:
+		return switchCases(stmt.Body)
+	case *ast.For:
+		return switchCases(stmt.Body)
+	case *ast.Label:
+		return switchCases(stmt.Body)
+	default:
+		return nil
+	}
+}
+
+// labels returns the labeled statements in a function body.
+func labels(stmt ast.Stmt) []*ast.Label {
+	switch stmt := stmt.(type) {
+	case *ast.Label:
+		return append([]*ast.Label{stmt}, labels(stmt.Body)...)
+	case *ast.Block:
+		var ll []*ast.Label
+		for _, s := range stmt.Statements {
+			ll = append(ll, labels(s)...)
+		}
+		return ll
+	case *ast.If:
+		return append(labels(stmt.Then), labels(stmt.Else)...)
+	case *ast.While:
+		return labels(stmt.Body)
+	case *ast.Do:
+		return labels(stmt.Body)
+	case *ast.For:
+		return labels(stmt.Body)
+	case *ast.Switch:
+		return labels(stmt.Body)
+	case *ast.Case:
+		return labels(stmt.Body)
+	case *ast.Default:
+		return labels(stmt.Body)
+	default:
+		return nil
+	}
+}
+
+// compoundLits returns the compound literals in a statement or
+// expression. The operand of sizeof isn't evaluated so its literals
+// are skipped.
+func compoundLits(node ast.Node) []*ast.CompoundLit {
+	var lits []*ast.CompoundLit
+	add := func(nodes ...ast.Node) {
+		for _, n := range nodes {
+			if n != nil {
+				lits = append(lits, compoundLits(n)...)
+			}
+		}
+	}
+	switch n := node.(type) {
+	case *ast.CompoundLit:
+		lits = append(lits, n)
+		add(n.Init)
+	case *ast.InitList:
+		for _, v := range n.Values {
+			add(v)
+		}
+	case *ast.Designation:
+		for _, d := range n.Designators {
+			add(d.Index)
+		}
+		add(n.Value)
+	case *ast.Block:
+		for _, s := range n.Statements {
+			add(s)
+		}
+	case *ast.VarDec:
+		add(n.Value)
+	case *ast.ExprStmt:
+		add(n.Expr)
+	case *ast.Ret:
+		add(n.Value)
+	case *ast.If:
+		add(n.Condition, n.Then, n.Else)
+	case *ast.While:
+		add(n.Condition, n.Body)
+	case *ast.Do:
+		add(n.Body, n.Condition)
+	case *ast.For:
+		for _, s := range n.Setup {
+			add(s)
+		}
+		add(n.Condition, n.Increment, n.Body)
+	case *ast.Switch:
+		add(n.Value, n.Body)
+	case *ast.Case:
+		add(n.Body)
+	case *ast.Default:
+		add(n.Body)
+	case *ast.Label:
+		add(n.Body)
+	case *ast.BinaryOp:
+		add(n.Left, n.Right)
+	case *ast.UnaryOp:
+		add(n.Value)
+	case *ast.Assign:
+		add(n.Target, n.Value)
+	case *ast.CompoundAssign:
+		add(n.Target, n.Value)
+	case *ast.IncDec:
+		add(n.Target)
+	case *ast.Ternary:
+		add(n.Condition, n.Then, n.Else)
+	case *ast.Comma:
+		add(n.Left, n.Right)
+	case *ast.Cast:
+		add(n.Value)
+	case *ast.Index:
+		add(n.Array, n.Index)
+	case *ast.Member:
+		add(n.Value)
+	case *ast.Call:
+		add(n.Func)
+		for _, a := range n.Arguments {
+			add(a)
+		}
+	case *ast.VaStart:
+		add(n.List, n.Last)
+	case *ast.VaArg:
+		add(n.List)
+	case *ast.VaEnd:
+		add(n.List)
+	}
+	return lits
+}
+
+// allocateLiterals reserves stack space for the compound literals in
+// the body of a function. Each literal gets its own storage for the
+// whole function, which outlives the block it's in.
+func (c *Compiler) allocateLiterals(body *ast.Block) error {
+	for _, lit := range compoundLits(body) {
+		typ, err := c.objectType("compound literal", lit.Type, lit.Init)
 		if err != nil {
 			return err
 		}
-		c.emitf("jmp %s", loop.Continue)
-		return nil
-	default:
-		return fmt.Errorf("cannot compile: %s", stmt)
+		c.scope.Offset -= (typ.Size() + 3) &^ 3
+		c.literals[lit] = &Local{
+			Type:     typ,
+			Offset:   c.scope.TotalOffset(),
+			Declared: true,
+		}
 	}
+	c.reserve()
+	return nil
 }
 
-func (c *Compiler) forLoop(f *ast.For) error {
-	loop := c.enterLoopScope()
-	if err := c.allocate(f.Setup); err != nil {
-		return err
-	}
-	skipInc := c.label("for_skip_inc")
-	if err := c.stmt(f.Setup); err != nil {
-		return err
-	}
-	c.emitf("jmp %s", skipInc)
-	c.emitf("%s:", loop.Continue)
-	if err := c.expr(f.Increment); err != nil {
-		return err
+// declareLabels maps the labels of a function to assembly labels.
+// Labels have function scope so they're declared before the body is
+// compiled.
+func (c *Compiler) declareLabels(body *ast.Block) ([]*ast.Label, error) {
+	c.gotos = make(map[string]*Label)
+	ll := labels(body)
+	for _, l := range ll {
+		if _, ok := c.gotos[l.Name]; ok {
+			return nil, fmt.Errorf("duplicate label: %s", l.Name)
+		}
+		c.gotos[l.Name] = &Label{Asm: c.label("label_" + l.Name)}
 	}
-	c.emitf("%s:", skipInc)
-	if err := c.expr(f.Condition); err != nil {
+	return ll, nil
+}
+
+// caseLabel emits the label of a case or default statement followed
+// by the statement it labels.
+func (c *Compiler) caseLabel(stmt, body ast.Stmt) error {
+	sw, err := c.scope.FindSwitch()
+	if err != nil {
 		return err
 	}
-	c.emitf("cmpl $0, %%eax")
-	c.emitf("je %s", loop.Break)
-	if err := c.stmt(f.Body); err != nil {
-		return err
+	label, ok := sw.Labels[stmt]
+	if !ok {
+		return fmt.Errorf("case label not within a switch statement")
 	}
-	c.emitf("jmp %s", loop.Continue)
-	c.emitf("%s:", loop.Break)
-	c.deallocate()
-	c.leaveScope()
-	return nil
+	c.emitf("%s:", label)
+	return c.stmt(body)
 }
 
 func (c *Compiler) whileLoop(w *ast.While) error {
@@ -302,22 +2372,73 @@ func (c *Compiler) doLoop(d *ast.Do) error {
 }
 
 func (c *Compiler) varDec(dec *ast.VarDec) error {
-	if dec.Value != nil {
-		if err := c.expr(dec.Value); err != nil {
-			return err
-		}
-	} else {
-		c.emitf("movl $0, %%eax")
-	}
 	loc, err := c.scope.Local(dec.Name)
 	if err != nil {
 		return err
 	}
+	switch dec.Storage {
+	case ast.Extern:
+		loc.Declared = true
+		return nil
+	case ast.Static:
+		// static locals are initialized once, before the program starts.
+		data, err := c.staticInit(loc.Type, dec.Value)
+		if err != nil {
+			return fmt.Errorf("invalid initializer for %s: %v", dec.Name, err)
+		}
+		c.global(loc.Name).Data = data
+		loc.Declared = true
+		return nil
+	}
+	if dec.Value == nil && types.IsArray(loc.Type) {
+		loc.Declared = true
+		return nil
+	}
+	if err := c.initLocal(loc.Type, dec.Value, loc.Offset); err != nil {
+		return fmt.Errorf("invalid initializer for %s: %v", dec.Name, err)
+	}
 	loc.Declared = true
-	c.emitf("movl %%eax, %d(%%ebp)", loc.Offset)
 	return nil
 }
 
+// initLocal stores the initial value of an object of type t located
+// at offset(%ebp). Members without an initializer are set to zero.
+func (c *Compiler) initLocal(t types.Type, init ast.Expr, offset int) error {
+	t = types.Unqualified(t)
+	if init == nil {
+		c.zero(offset, t.Size())
+		return nil
+	}
+	values, _, err := c.initValues(t, init)
+	if err != nil {
+		return err
+	}
+	if _, ok := init.(*ast.InitList); ok || types.IsArray(t) {
+		c.zero(offset, t.Size())
+	}
+	for _, v := range values {
+		if err := c.expr(v.Value); err != nil {
+			return err
+		}
+		c.convert(v.Type)
+		c.store(v.Type, fmt.Sprintf("%d(%%ebp)", offset+v.Offset))
+	}
+	return nil
+}
+
+// zero clears size bytes of the stack starting at offset(%ebp).
+func (c *Compiler) zero(offset, size int) {
+	for off := 0; off < size; {
+		if size-off >= 4 {
+			c.emitf("movl $0, %d(%%ebp)", offset+off)
+			off += 4
+		} else {
+			c.emitf("movb $0, %d(%%ebp)", offset+off)
+			off++
+		}
+	}
+}
+
 func (c *Compiler) ternary(tern *ast.Ternary) error {
 	afterThen, end := c.label("tern_after_then"), c.label("tern_end")
 	if err := c.expr(tern.Condition); err != nil {
@@ -359,60 +2480,304 @@ func (c *Compiler) _if(ife *ast.If) error {
 }
 
 func (c *Compiler) assign(assign *ast.Assign) error {
+	t, err := c.typeOf(assign.Target)
+	if err != nil {
+		return err
+	}
+	if types.IsArray(t) {
+		return fmt.Errorf("cannot assign to array: %s", assign.Target)
+	}
+	if err := c.assignable(t, assign.Value); err != nil {
+		return err
+	}
+	if err := c.expr(assign.Value); err != nil {
+		return err
+	}
+	c.convert(t)
+	dst, err := c.lvalue(assign.Target)
+	if err != nil {
+		return err
+	}
+	c.store(t, dst)
+	return nil
+}
+
+func (c *Compiler) compoundAssign(assign *ast.CompoundAssign) error {
+	t, err := c.typeOf(assign.Target)
+	if err != nil {
+		return err
+	}
+	if types.IsArray(t) || types.IsStruct(t) {
+		return fmt.Errorf("invalid compound assignment to %s: %s", t, assign.Target)
+	}
+	op := strings.TrimSuffix(assign.Op, "=")
+	vt, err := c.typeOf(assign.Value)
+	if err != nil {
+		return err
+	}
+	vt = types.Decay(vt)
+	// the result has the type of the target, which rules out
+	// pointer values even where the binary operator allows them.
+	if !validOperands(op, t, vt) || types.IsPointer(vt) {
+		return fmt.Errorf("invalid operands to %s: %s", assign.Op, assign)
+	}
 	if err := c.expr(assign.Value); err != nil {
 		return err
 	}
-	loc, err := c.scope.DeclaredLocal(assign.Var.Name)
+	if types.IsPointer(t) {
+		c.scale("%eax", elemSize(t))
+	}
+	dst, err := c.lvalue(assign.Target)
+	if err != nil {
+		return err
+	}
+	if dst == indirect {
+		c.emitf("pushl %%ecx")
+	}
+	c.load(t, dst, "%ecx")
+	if err := c.operator(op, types.IsUnsigned(commonType(op, t, vt))); err != nil {
+		return fmt.Errorf("invalid compound assignment: %s", assign)
+	}
+	c.convert(t)
+	if dst == indirect {
+		c.emitf("pop %%ecx")
+	}
+	c.store(t, dst)
+	return nil
+}
+
+func (c *Compiler) incDec(inc *ast.IncDec) error {
+	t, err := c.typeOf(inc.Target)
+	if err != nil {
+		return err
+	}
+	if types.IsArray(t) || types.IsStruct(t) {
+		return fmt.Errorf("cannot %s %s: %s", inc.Op, t, inc.Target)
+	}
+	dst, err := c.lvalue(inc.Target)
 	if err != nil {
 		return err
 	}
-	c.emitf("movl %%eax, %d(%%ebp)", loc.Offset)
+	var instr string
+	switch {
+	case types.IsPointer(t) && inc.Op == "++":
+		instr = fmt.Sprintf("addl $%d,", elemSize(t))
+	case types.IsPointer(t):
+		instr = fmt.Sprintf("subl $%d,", elemSize(t))
+	case t == types.Bool && inc.Op == "++":
+		instr = "movb $1,"
+	case t == types.Bool:
+		instr = "xorb $1,"
+	case t.Size() == 2 && inc.Op == "++":
+		instr = "incw"
+	case t.Size() == 2:
+		instr = "decw"
+	case t.Size() == 1 && inc.Op == "++":
+		instr = "incb"
+	case t.Size() == 1:
+		instr = "decb"
+	case inc.Op == "++":
+		instr = "incl"
+	default:
+		instr = "decl"
+	}
+	if inc.Postfix {
+		c.load(t, dst, "%eax")
+		c.emitf("%s %s", instr, dst)
+	} else {
+		c.emitf("%s %s", instr, dst)
+		c.load(t, dst, "%eax")
+	}
 	return nil
 }
 
 func (c *Compiler) variable(v *ast.Var) error {
+	if fn := c.function(v); fn != nil {
+		c.emitf("movl $_%s, %%eax", fn.Name)
+		return nil
+	}
 	loc, err := c.scope.DeclaredLocal(v.Name)
 	if err != nil {
 		return err
 	}
-	c.emitf("movl %d(%%ebp), %%eax", loc.Offset)
+	if loc.Constant {
+		c.emitf("movl $%d, %%eax", loc.Value)
+		return nil
+	}
+	c.load(loc.Type, loc.Operand(), "%eax")
 	return nil
 }
 
 func (c *Compiler) ret(ret *ast.Ret) error {
-	if err := c.expr(ret.Value); err != nil {
-		return err
+	result := types.Unqualified(c.fn.Type.Result)
+	switch {
+	case ret.Value == nil && result != types.Void:
+		return fmt.Errorf("return with no value in function returning %s: %s", result, c.fn.Name)
+	case ret.Value != nil && result == types.Void:
+		return fmt.Errorf("return with a value in function returning void: %s", c.fn.Name)
+	}
+	if ret.Value != nil {
+		if err := c.assignable(result, ret.Value); err != nil {
+			return fmt.Errorf("bad return value in %s: %v", c.fn.Name, err)
+		}
+		if err := c.expr(ret.Value); err != nil {
+			return err
+		}
+		c.convert(result)
 	}
 	c.prologue()
 	return nil
 }
 
+// call compiles a function call. Named functions are called directly
+// and any other callee is evaluated after the arguments are pushed
+// and called indirectly through %eax.
 func (c *Compiler) call(call *ast.Call) error {
-
-	dec, ok := c.funcs[call.Name]
-	if !ok {
-		return fmt.Errorf("undefined function: %s", call.Name)
+	fn, err := c.funcType(call)
+	if err != nil {
+		return err
 	}
-	if len(dec.Params) != len(call.Arguments) {
+	if n := len(fn.Params); len(call.Arguments) < n || !fn.Variadic && len(call.Arguments) > n {
+		want := fmt.Sprint(n)
+		if fn.Variadic {
+			want = "at least " + want
+		}
 		return fmt.Errorf(
-			"bad call, wanted %d arguments, got %d: %s",
-			len(dec.Params), len(call.Arguments), call.Name,
+			"bad call, wanted %s arguments, got %d: %s",
+			want, len(call.Arguments), call.Func,
 		)
 	}
 
-	for i := range call.Arguments {
-		arg := call.Arguments[len(call.Arguments)-i-1]
+	size := 0
+	for i := len(call.Arguments) - 1; i >= 0; i-- {
+		arg := call.Arguments[i]
+		param, err := c.argType(fn, i, arg)
+		if err != nil {
+			return fmt.Errorf("bad argument %d to %s: %v", i+1, call.Func, err)
+		}
 		if err := c.expr(arg); err != nil {
 			return err
 		}
-		c.emitf("pushl %%eax")
+		c.convert(param)
+		if s, ok := param.(*types.Struct); ok {
+			n := paramSize(s)
+			c.emitf("subl $%d, %%esp", n)
+			c.store(s, "(%esp)")
+			size += n
+		} else {
+			c.emitf("pushl %%eax")
+			size += 4
+		}
+	}
+	if v, ok := call.Func.(*ast.Var); ok && c.function(v) != nil {
+		c.emitf("call _%s", v.Name)
+	} else {
+		if err := c.expr(call.Func); err != nil {
+			return err
+		}
+		c.emitf("call *%%eax")
+	}
+	c.emitf("addl $%d, %%esp", size)
+	return nil
+}
+
+// argType returns the type argument i of a call to fn is converted
+// to. The variadic arguments undergo the default argument promotions.
+func (c *Compiler) argType(fn *types.Func, i int, arg ast.Expr) (types.Type, error) {
+	if i >= len(fn.Params) {
+		t, err := c.typeOf(arg)
+		if err != nil {
+			return nil, err
+		}
+		return types.Promote(types.Decay(t)), nil
+	}
+	param, err := c.completeType(fn.Params[i])
+	if err != nil {
+		return nil, err
+	}
+	param = types.Unqualified(param)
+	if err := c.assignable(param, arg); err != nil {
+		return nil, err
+	}
+	return param, nil
+}
+
+// vaList checks that expr is a va_list and evaluates its address
+// into %eax.
+func (c *Compiler) vaList(expr ast.Expr) error {
+	t, err := c.typeOf(expr)
+	if err != nil {
+		return err
+	}
+	if !types.Identical(t, types.VaList) {
+		return fmt.Errorf("argument is not of type va_list: %s", expr)
+	}
+	return c.addr(expr)
+}
+
+// vaStart points a va_list at the first variadic argument, which
+// follows the named parameters on the stack.
+func (c *Compiler) vaStart(v *ast.VaStart) error {
+	if !c.fn.Type.Variadic {
+		return fmt.Errorf("va_start used in function with fixed arguments: %s", c.fn.Name)
+	}
+	last, ok := v.Last.(*ast.Var)
+	if !ok || last.Name != c.fn.Params[len(c.fn.Params)-1] {
+		return fmt.Errorf("second argument to va_start is not the last named parameter: %s", v.Last)
+	}
+	offset := 8
+	for _, p := range c.fn.Type.Params {
+		t, err := c.completeType(p)
+		if err != nil {
+			return err
+		}
+		offset += paramSize(t)
+	}
+	if err := c.vaList(v.List); err != nil {
+		return err
+	}
+	c.emitf("leal %d(%%ebp), %%ecx", offset)
+	c.emitf("movl %%ecx, (%%eax)")
+	return nil
+}
+
+// vaArg loads the variadic argument a va_list points to and advances
+// it to the next one.
+func (c *Compiler) vaArg(v *ast.VaArg) error {
+	t, err := c.typeOf(v)
+	if err != nil {
+		return err
+	}
+	if t == types.Void || types.IsArray(t) || !types.IsComplete(t) {
+		return fmt.Errorf("invalid type for va_arg: %s", t)
+	}
+	if types.Promote(t) != t {
+		return fmt.Errorf("%s is promoted to int when passed through ...", t)
+	}
+	if err := c.vaList(v.List); err != nil {
+		return err
 	}
-	c.emitf("call _%s", call.Name)
-	c.emitf("addl $%d, %%esp", len(call.Arguments)*4)
+	c.emitf("movl %%eax, %%ecx")
+	c.emitf("movl (%%ecx), %%eax")
+	c.emitf("addl $%d, (%%ecx)", paramSize(t))
+	c.load(t, "(%eax)", "%eax")
 	return nil
 }
 
+// paramSize returns the number of stack bytes used to pass a value of
+// type t. Arguments are padded to a multiple of 4.
+func paramSize(t types.Type) int {
+	return (t.Size() + 3) &^ 3
+}
+
 func (c *Compiler) binaryOp(binary *ast.BinaryOp) error {
+	if binary.Op == "&&" || binary.Op == "||" {
+		return c.logicalOp(binary)
+	}
+	left, right, err := c.operandTypes(binary)
+	if err != nil {
+		return err
+	}
 	if err := c.expr(binary.Left); err != nil {
 		return err
 	}
@@ -421,7 +2786,104 @@ func (c *Compiler) binaryOp(binary *ast.BinaryOp) error {
 		return err
 	}
 	c.emitf("pop %%ecx")
-	switch binary.Op {
+	return c.pointerOp(binary, left, right)
+}
+
+// pointerOp applies a binary operator whose operands may be pointers.
+// Integers added to or subtracted from a pointer are scaled by the
+// size of the element type, and the difference of two pointers is
+// the number of elements between them.
+func (c *Compiler) pointerOp(binary *ast.BinaryOp, left, right types.Type) error {
+	if !validOperands(binary.Op, left, right) {
+		return fmt.Errorf("invalid operands to binary %s: %s", binary.Op, binary)
+	}
+	lptr, rptr := types.IsPointer(left), types.IsPointer(right)
+	switch {
+	case binary.Op == "+" && lptr:
+		c.scale("%eax", elemSize(left))
+	case binary.Op == "+" && rptr:
+		c.scale("%ecx", elemSize(right))
+	case binary.Op == "-" && lptr && !rptr:
+		c.scale("%eax", elemSize(left))
+	}
+	if err := c.operator(binary.Op, types.IsUnsigned(commonType(binary.Op, left, right))); err != nil {
+		return fmt.Errorf("invalid binary op: %s", binary)
+	}
+	if binary.Op == "-" && lptr && rptr {
+		if size := elemSize(left); size > 1 {
+			c.emitf("movl $%d, %%ecx", size)
+			c.emitf("cltd")
+			c.emitf("idivl %%ecx")
+		}
+	}
+	return nil
+}
+
+// validOperands reports whether a binary operator can be applied to
+// operands of the provided types. Pointers can only be compared, have
+// an integer added or subtracted, or be subtracted from each other.
+func validOperands(op string, left, right types.Type) bool {
+	if types.IsStruct(left) || types.IsStruct(right) {
+		return false
+	}
+	lptr, rptr := types.IsPointer(left), types.IsPointer(right)
+	switch {
+	case !lptr && !rptr, isComparison(op):
+		return true
+	case op == "+":
+		return !lptr || !rptr
+	case op == "-":
+		return lptr
+	default:
+		return false
+	}
+}
+
+// scale multiplies the integer in reg by the element size of a pointer.
+func (c *Compiler) scale(reg string, size int) {
+	if size != 1 {
+		c.emitf("imull $%d, %s", size, reg)
+	}
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	default:
+		return false
+	}
+}
+
+// logicalOp compiles && and || so that the right operand is only
+// evaluated when the left one doesn't determine the result.
+func (c *Compiler) logicalOp(binary *ast.BinaryOp) error {
+	jump := "je"
+	if binary.Op == "||" {
+		jump = "jne"
+	}
+	end := c.label("logical_end")
+	if err := c.expr(binary.Left); err != nil {
+		return err
+	}
+	c.emitf("cmpl $0, %%eax")
+	c.emitf("%s %s", jump, end)
+	if err := c.expr(binary.Right); err != nil {
+		return err
+	}
+	c.emitf("%s:", end)
+	c.emitf("cmpl $0, %%eax")
+	c.emitf("movl $0, %%eax")
+	c.emitf("setne %%al")
+	return nil
+}
+
+// operator applies a binary operator to the left operand in %ecx and
+// the right operand in %eax. The result is left in %eax. Division,
+// right shifts and relational operators use their unsigned variants
+// when unsigned is true.
+func (c *Compiler) operator(op string, unsigned bool) error {
+	switch op {
 	case "+":
 		c.emitf("addl %%ecx, %%eax")
 	case "-":
@@ -429,15 +2891,30 @@ func (c *Compiler) binaryOp(binary *ast.BinaryOp) error {
 		c.emitf("subl %%ecx, %%eax")
 	case "*":
 		c.emitf("imul %%ecx, %%eax")
-	case "/":
+	case "/", "%":
+		c.emitf("xchg %%eax, %%ecx")
+		if unsigned {
+			c.emitf("xorl %%edx, %%edx")
+			c.emitf("divl %%ecx")
+		} else {
+			c.emitf("cltd")
+			c.emitf("idivl %%ecx")
+		}
+		if op == "%" {
+			c.emitf("movl %%edx, %%eax")
+		}
+	case "&":
+		c.emitf("andl %%ecx, %%eax")
+	case "|":
+		c.emitf("orl %%ecx, %%eax")
+	case "^":
+		c.emitf("xorl %%ecx, %%eax")
+	case "<<":
 		c.emitf("xchg %%eax, %%ecx")
-		c.emitf("movl $0, %%edx")
-		c.emitf("idiv %%ecx, %%eax")
-	case "%":
+		c.emitf("sall %%cl, %%eax")
+	case ">>":
 		c.emitf("xchg %%eax, %%ecx")
-		c.emitf("movl $0, %%edx")
-		c.emitf("idiv %%ecx, %%eax")
-		c.emitf("movl %%edx, %%eax")
+		c.emitf("%s %%cl, %%eax", setcc("sarl", "shrl", unsigned))
 	case "==":
 		c.emitf("cmpl %%eax, %%ecx")
 		c.emitf("movl $0, %%eax")
@@ -449,38 +2926,37 @@ func (c *Compiler) binaryOp(binary *ast.BinaryOp) error {
 	case ">":
 		c.emitf("cmpl %%eax, %%ecx")
 		c.emitf("movl $0, %%eax")
-		c.emitf("setg %%al")
+		c.emitf("%s %%al", setcc("setg", "seta", unsigned))
 	case ">=":
 		c.emitf("cmpl %%eax, %%ecx")
 		c.emitf("movl $0, %%eax")
-		c.emitf("setge %%al")
+		c.emitf("%s %%al", setcc("setge", "setae", unsigned))
 	case "<":
 		c.emitf("cmpl %%eax, %%ecx")
 		c.emitf("movl $0, %%eax")
-		c.emitf("setl %%al")
+		c.emitf("%s %%al", setcc("setl", "setb", unsigned))
 	case "<=":
 		c.emitf("cmpl %%eax, %%ecx")
 		c.emitf("movl $0, %%eax")
-		c.emitf("setle %%al")
-	case "||":
-		c.emitf("orl %%eax, %%ecx")
-		c.emitf("movl $0, %%eax")
-		c.emitf("setne %%al")
-	case "&&":
-		c.emitf("cmpl $0, %%ecx")
-		c.emitf("setne %%cl")
-		c.emitf("cmpl $0, %%eax")
-		c.emitf("movl $0, %%eax")
-		c.emitf("setne %%al")
-		c.emitf("andb %%cl, %%al")
+		c.emitf("%s %%al", setcc("setle", "setbe", unsigned))
 	default:
-		return fmt.Errorf("invalid binary op: %s", binary)
+		return fmt.Errorf("invalid operator: %s", op)
 	}
 	return nil
 }
 
-func (c *Compiler) preable(name string) {
-	c.emitf(".globl _%s", name)
+// setcc returns the signed or unsigned variant of a set instruction.
+func setcc(signed, unsigned string, isUnsigned bool) string {
+	if isUnsigned {
+		return unsigned
+	}
+	return signed
+}
+
+func (c *Compiler) preable(name string, static bool) {
+	if !static {
+		c.emitf(".globl _%s", name)
+	}
 	c.emitf("_%s:", name)
 	c.emitf("pushl %%ebp")
 	c.emitf("movl %%esp, %%ebp")
@@ -493,19 +2969,65 @@ func (c *Compiler) prologue() {
 }
 
 func (c *Compiler) allocate(stmts ...ast.Stmt) error {
+	var enums []*ast.EnumDec
 	for _, s := range stmts {
-		if dec, ok := s.(*ast.VarDec); ok {
-			if err := c.scope.Declare(dec); err != nil {
+		switch dec := s.(type) {
+		case *ast.VarDec:
+			switch dec.Storage {
+			case ast.Static:
+				if err := c.staticDec(dec); err != nil {
+					return err
+				}
+				continue
+			case ast.Extern:
+				if err := c.externDec(dec); err != nil {
+					return err
+				}
+				continue
+			}
+			typ, err := c.objectType(dec.Name, dec.Type, dec.Value)
+			if err != nil {
+				return err
+			}
+			if err := c.scope.Declare(dec.Name, typ); err != nil {
+				return err
+			}
+		case *ast.EnumDec:
+			// enumerators are defined up front so they can be used
+			// in the array sizes of the variables which follow them.
+			if err := c.enumDec(dec); err != nil {
 				return err
 			}
+			enums = append(enums, dec)
 		}
 	}
-	c.emitf("subl $%d, %%esp", -c.scope.Offset)
+	// like variables, enumerators aren't visible to the statements
+	// before their declaration.
+	for _, dec := range enums {
+		c.declareEnumerators(dec, false)
+	}
+	c.reserve()
 	return nil
 }
 
-func (c *Compiler) deallocate() {
-	c.emitf("addl $%d, %%esp", -c.scope.Offset)
+// declareEnumerators marks the enumerators of a block scope enum
+// as declared once the statement defining them is reached.
+func (c *Compiler) declareEnumerators(dec *ast.EnumDec, declared bool) {
+	for _, e := range dec.Enumerators {
+		if loc, ok := c.scope.Locals[e.Name]; ok && loc.Constant {
+			loc.Declared = declared
+		}
+	}
+}
+
+// reserve grows the stack frame of the function to fit the variables
+// of the current scope. Blocks don't adjust %esp, the whole frame is
+// allocated on entry so jumping into or out of a block with goto or
+// a case label can't unbalance the stack.
+func (c *Compiler) reserve() {
+	if size := -c.scope.TotalOffset(); size > c.frame {
+		c.frame = size
+	}
 }
 
 func (c *Compiler) block(b *ast.Block) error {
@@ -518,12 +3040,14 @@ func (c *Compiler) block(b *ast.Block) error {
 			return err
 		}
 	}
-	c.deallocate()
 	c.leaveScope()
 	return nil
 }
 
 func (c *Compiler) addFuncDec(f *ast.FuncDec) error {
+	if _, ok := c.scope.Locals[f.Name]; ok {
+		return fmt.Errorf("redeclared as a different kind of symbol: %s", f.Name)
+	}
 	prev, ok := c.funcs[f.Name]
 	if ok {
 		if prev.Body != nil && f.Body != nil {
@@ -532,12 +3056,15 @@ func (c *Compiler) addFuncDec(f *ast.FuncDec) error {
 		if prev.Body == nil && f.Body == nil {
 			return fmt.Errorf("duplicate function prototype: %s", f.Name)
 		}
-		if len(prev.Params) != len(f.Params) {
+		if !types.Identical(prev.Type, f.Type) {
 			return fmt.Errorf("definition doesn't match prototype: %s", f.Name)
 		}
-		if f.Body == nil {
-			return nil
-		}
+	}
+	if err := c.link(f.Name, f.Storage, true); err != nil {
+		return err
+	}
+	if ok && f.Body == nil {
+		return nil
 	}
 	c.funcs[f.Name] = f
 	return nil
@@ -550,18 +3077,50 @@ func (c *Compiler) funcDec(f *ast.FuncDec) error {
 	if f.Body == nil {
 		return nil
 	}
+	if types.IsStruct(f.Type.Result) {
+		return fmt.Errorf("functions returning %s are not supported: %s", f.Type.Result, f.Name)
+	}
+	c.fn = f
+	labels, err := c.declareLabels(f.Body)
+	if err != nil {
+		return err
+	}
 	c.enterScope()
+	offset := 8
 	for i, p := range f.Params {
-		if err := c.scope.AddParam(i, p); err != nil {
+		typ, err := c.objectType(p, f.Type.Params[i], nil)
+		if err != nil {
+			return err
+		}
+		if err := c.scope.AddParam(p, typ, offset); err != nil {
 			return err
 		}
+		offset += paramSize(typ)
+	}
+	c.preable(f.Name, c.linkage[f.Name] == ast.Static)
+	// the body is compiled first since the size of the frame
+	// allocated before it isn't known until then.
+	asm := c.asm
+	c.asm, c.frame = &strings.Builder{}, 0
+	if err := c.allocateLiterals(f.Body); err != nil {
+		return err
 	}
-	c.preable(f.Name)
 	if err := c.block(f.Body); err != nil {
 		return err
 	}
+	for _, l := range labels {
+		if !c.gotos[l.Name].Used {
+			c.warnf("%s: label %s defined but not used", l.Tok.Pos, l.Name)
+		}
+	}
 	c.emitf("movl $0, %%eax")
 	c.prologue()
+	body := c.asm.String()
+	c.asm = asm
+	if c.frame > 0 {
+		c.emitf("subl $%d, %%esp", c.frame)
+	}
+	c.asm.WriteString(body)
 	c.leaveScope()
 	return nil
 }
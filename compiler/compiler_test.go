@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"testing"
 
+	"github.com/icholy/cc/parser"
 	"gotest.tools/assert"
 	"gotest.tools/fs"
 )
@@ -150,6 +151,1080 @@ func TestOutput(t *testing.T) {
 			SrcPath:  "../testdata/stage_9/valid/mutual_recursion.c",
 			ExitCode: 12,
 		},
+		{
+			Name:     "block_comment.c",
+			SrcPath:  "../testdata/stage_10/valid/block_comment.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "hex.c",
+			SrcPath:  "../testdata/stage_11/valid/hex.c",
+			ExitCode: 31,
+		},
+		{
+			Name:     "octal.c",
+			SrcPath:  "../testdata/stage_11/valid/octal.c",
+			ExitCode: 15,
+		},
+		{
+			Name:     "binary.c",
+			SrcPath:  "../testdata/stage_11/valid/binary.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "hex_unsigned.c",
+			SrcPath:  "../testdata/stage_11/valid/hex_unsigned.c",
+			ExitCode: 4,
+		},
+		{
+			Name:    "hello_world.c",
+			SrcPath: "../testdata/stage_12/valid/hello_world.c",
+			Ouput:   "Hello, World!\n",
+		},
+		{
+			Name:     "escapes.c",
+			SrcPath:  "../testdata/stage_12/valid/escapes.c",
+			ExitCode: 247,
+		},
+		{
+			Name:     "octal_escape.c",
+			SrcPath:  "../testdata/stage_12/valid/octal_escape.c",
+			ExitCode: 58,
+		},
+		{
+			Name:     "signed_char.c",
+			SrcPath:  "../testdata/stage_12/valid/signed_char.c",
+			ExitCode: 1,
+		},
+		{
+			Name:    "string_concat.c",
+			SrcPath: "../testdata/stage_13/valid/concat.c",
+			Ouput:   "Hello, World!\n",
+		},
+		{
+			Name:    "string_escapes.c",
+			SrcPath: "../testdata/stage_13/valid/escapes.c",
+			Ouput:   "tab:\t quote:\" backslash:\\ octal:A hex:B\n\n",
+		},
+		{
+			Name:    "string_empty.c",
+			SrcPath: "../testdata/stage_13/valid/empty.c",
+			Ouput:   "\nx\n",
+		},
+		{
+			Name:     "string_duplicate.c",
+			SrcPath:  "../testdata/stage_13/valid/duplicate.c",
+			ExitCode: 1,
+		},
+		{
+			Name:     "bitwise_and.c",
+			SrcPath:  "../testdata/stage_14/valid/and.c",
+			ExitCode: 48,
+		},
+		{
+			Name:     "bitwise_or.c",
+			SrcPath:  "../testdata/stage_14/valid/or.c",
+			ExitCode: 19,
+		},
+		{
+			Name:     "bitwise_xor.c",
+			SrcPath:  "../testdata/stage_14/valid/xor.c",
+			ExitCode: 240,
+		},
+		{
+			Name:     "shl.c",
+			SrcPath:  "../testdata/stage_14/valid/shl.c",
+			ExitCode: 16,
+		},
+		{
+			Name:     "sar.c",
+			SrcPath:  "../testdata/stage_14/valid/sar.c",
+			ExitCode: 1,
+		},
+		{
+			Name:     "shift_var.c",
+			SrcPath:  "../testdata/stage_14/valid/shift_var.c",
+			ExitCode: 16,
+		},
+		{
+			Name:     "bitwise_precedence.c",
+			SrcPath:  "../testdata/stage_14/valid/precedence.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "flags.c",
+			SrcPath:  "../testdata/stage_14/valid/flags.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "postfix_inc.c",
+			SrcPath:  "../testdata/stage_15/valid/postfix_inc.c",
+			ExitCode: 56,
+		},
+		{
+			Name:     "prefix_inc.c",
+			SrcPath:  "../testdata/stage_15/valid/prefix_inc.c",
+			ExitCode: 66,
+		},
+		{
+			Name:     "postfix_dec.c",
+			SrcPath:  "../testdata/stage_15/valid/postfix_dec.c",
+			ExitCode: 54,
+		},
+		{
+			Name:     "prefix_dec.c",
+			SrcPath:  "../testdata/stage_15/valid/prefix_dec.c",
+			ExitCode: 44,
+		},
+		{
+			Name:     "for_inc.c",
+			SrcPath:  "../testdata/stage_15/valid/for_inc.c",
+			ExitCode: 45,
+		},
+		{
+			Name:     "compound.c",
+			SrcPath:  "../testdata/stage_15/valid/compound.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "compound_bitwise.c",
+			SrcPath:  "../testdata/stage_15/valid/compound_bitwise.c",
+			ExitCode: 41,
+		},
+		{
+			Name:     "compound_value.c",
+			SrcPath:  "../testdata/stage_15/valid/compound_value.c",
+			ExitCode: 28,
+		},
+		{
+			Name:     "unary_postfix.c",
+			SrcPath:  "../testdata/stage_15/valid/unary_postfix.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "plus_plus_plus.c",
+			SrcPath:  "../testdata/stage_15/valid/plus_plus_plus.c",
+			ExitCode: 23,
+		},
+		{
+			Name:     "and_short_circuit.c",
+			SrcPath:  "../testdata/stage_16/valid/and_short_circuit.c",
+			ExitCode: 0,
+		},
+		{
+			Name:     "or_short_circuit.c",
+			SrcPath:  "../testdata/stage_16/valid/or_short_circuit.c",
+			ExitCode: 1,
+		},
+		{
+			Name:     "and_evaluates_right.c",
+			SrcPath:  "../testdata/stage_16/valid/and_evaluates_right.c",
+			Ouput:    "y",
+			ExitCode: 1,
+		},
+		{
+			Name:     "or_evaluates_right.c",
+			SrcPath:  "../testdata/stage_16/valid/or_evaluates_right.c",
+			Ouput:    "z",
+			ExitCode: 1,
+		},
+		{
+			Name:     "guard.c",
+			SrcPath:  "../testdata/stage_16/valid/guard.c",
+			ExitCode: 1,
+		},
+		{
+			Name:     "side_effects.c",
+			SrcPath:  "../testdata/stage_16/valid/side_effects.c",
+			Ouput:    "2\n",
+			ExitCode: 12,
+		},
+		{
+			Name:     "nested.c",
+			SrcPath:  "../testdata/stage_16/valid/nested.c",
+			Ouput:    "b",
+			ExitCode: 1,
+		},
+		{
+			Name:     "global.c",
+			SrcPath:  "../testdata/stage_17/valid/global.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "global_uninitialized.c",
+			SrcPath:  "../testdata/stage_17/valid/global_uninitialized.c",
+			ExitCode: 0,
+		},
+		{
+			Name:     "global_assign.c",
+			SrcPath:  "../testdata/stage_17/valid/global_assign.c",
+			ExitCode: 14,
+		},
+		{
+			Name:     "global_shadow.c",
+			SrcPath:  "../testdata/stage_17/valid/global_shadow.c",
+			ExitCode: 45,
+		},
+		{
+			Name:     "global_shadow_later.c",
+			SrcPath:  "../testdata/stage_17/valid/global_shadow_later.c",
+			ExitCode: 73,
+		},
+		{
+			Name:     "global_tentative.c",
+			SrcPath:  "../testdata/stage_17/valid/global_tentative.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "global_const_expr.c",
+			SrcPath:  "../testdata/stage_17/valid/global_const_expr.c",
+			ExitCode: 112,
+		},
+		{
+			Name:     "global_param_shadow.c",
+			SrcPath:  "../testdata/stage_17/valid/global_param_shadow.c",
+			ExitCode: 108,
+		},
+		{
+			Name:     "global_forward_use.c",
+			SrcPath:  "../testdata/stage_17/valid/global_forward_use.c",
+			ExitCode: 42,
+		},
+		{
+			Name:     "address_of.c",
+			SrcPath:  "../testdata/stage_18/valid/address_of.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "assign_through_pointer.c",
+			SrcPath:  "../testdata/stage_18/valid/assign_through_pointer.c",
+			ExitCode: 42,
+		},
+		{
+			Name:     "pointer_param.c",
+			SrcPath:  "../testdata/stage_18/valid/pointer_param.c",
+			ExitCode: 17,
+		},
+		{
+			Name:     "swap.c",
+			SrcPath:  "../testdata/stage_18/valid/swap.c",
+			ExitCode: 83,
+		},
+		{
+			Name:     "pointer_to_pointer.c",
+			SrcPath:  "../testdata/stage_18/valid/pointer_to_pointer.c",
+			ExitCode: 18,
+		},
+		{
+			Name:     "pointer_arithmetic.c",
+			SrcPath:  "../testdata/stage_18/valid/pointer_arithmetic.c",
+			ExitCode: 11,
+		},
+		{
+			Name:     "pointer_compare.c",
+			SrcPath:  "../testdata/stage_18/valid/pointer_compare.c",
+			ExitCode: 15,
+		},
+		{
+			Name:     "pointer_inc.c",
+			SrcPath:  "../testdata/stage_18/valid/pointer_inc.c",
+			ExitCode: 4,
+		},
+		{
+			Name:     "compound_through_pointer.c",
+			SrcPath:  "../testdata/stage_18/valid/compound_through_pointer.c",
+			ExitCode: 30,
+		},
+		{
+			Name:     "global_pointer.c",
+			SrcPath:  "../testdata/stage_18/valid/global_pointer.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "deref_string.c",
+			SrcPath:  "../testdata/stage_18/valid/deref_string.c",
+			Ouput:    "hi",
+			ExitCode: 99,
+		},
+		{
+			Name:     "array.c",
+			SrcPath:  "../testdata/stage_19/valid/array.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "array_init.c",
+			SrcPath:  "../testdata/stage_19/valid/array_init.c",
+			ExitCode: 200,
+		},
+		{
+			Name:     "array_partial_init.c",
+			SrcPath:  "../testdata/stage_19/valid/array_partial_init.c",
+			ExitCode: 15,
+		},
+		{
+			Name:     "array_loop.c",
+			SrcPath:  "../testdata/stage_19/valid/array_loop.c",
+			ExitCode: 91,
+		},
+		{
+			Name:     "array_decay.c",
+			SrcPath:  "../testdata/stage_19/valid/array_decay.c",
+			ExitCode: 18,
+		},
+		{
+			Name:     "array_param.c",
+			SrcPath:  "../testdata/stage_19/valid/array_param.c",
+			ExitCode: 33,
+		},
+		{
+			Name:     "array_2d.c",
+			SrcPath:  "../testdata/stage_19/valid/array_2d.c",
+			ExitCode: 120,
+		},
+		{
+			Name:     "array_global.c",
+			SrcPath:  "../testdata/stage_19/valid/array_global.c",
+			ExitCode: 82,
+		},
+		{
+			Name:     "array_index_commute.c",
+			SrcPath:  "../testdata/stage_19/valid/array_index_commute.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "array_compound.c",
+			SrcPath:  "../testdata/stage_19/valid/array_compound.c",
+			ExitCode: 28,
+		},
+		{
+			Name:     "array_address.c",
+			SrcPath:  "../testdata/stage_19/valid/array_address.c",
+			ExitCode: 14,
+		},
+		{
+			Name:     "array_string.c",
+			SrcPath:  "../testdata/stage_19/valid/array_string.c",
+			Ouput:    "ok\n",
+			ExitCode: 98,
+		},
+		{
+			Name:     "struct.c",
+			SrcPath:  "../testdata/stage_20/valid/struct.c",
+			ExitCode: 34,
+		},
+		{
+			Name:     "struct_init.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_init.c",
+			ExitCode: 21,
+		},
+		{
+			Name:     "struct_arrow.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_arrow.c",
+			ExitCode: 10,
+		},
+		{
+			Name:     "struct_assign.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_assign.c",
+			ExitCode: 67,
+		},
+		{
+			Name:     "struct_nested.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_nested.c",
+			ExitCode: 18,
+		},
+		{
+			Name:     "struct_linked_list.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_linked_list.c",
+			ExitCode: 36,
+		},
+		{
+			Name:     "struct_array.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_array.c",
+			ExitCode: 110,
+		},
+		{
+			Name:     "struct_param.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_param.c",
+			ExitCode: 185,
+		},
+		{
+			Name:     "struct_global.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_global.c",
+			ExitCode: 129,
+		},
+		{
+			Name:     "struct_anonymous.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_anonymous.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "struct_tag_shadow.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_tag_shadow.c",
+			ExitCode: 4,
+		},
+		{
+			Name:     "union.c",
+			SrcPath:  "../testdata/stage_20/valid/union.c",
+			ExitCode: 95,
+		},
+		{
+			Name:     "struct_forward.c",
+			SrcPath:  "../testdata/stage_20/valid/struct_forward.c",
+			ExitCode: 2,
+		},
+		{
+			Name:     "enum.c",
+			SrcPath:  "../testdata/stage_21/valid/enum.c",
+			ExitCode: 56,
+		},
+		{
+			Name:     "enum_variable.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_variable.c",
+			ExitCode: 1,
+		},
+		{
+			Name:     "enum_block.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_block.c",
+			ExitCode: 14,
+		},
+		{
+			Name:     "enum_array_size.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_array_size.c",
+			ExitCode: 9,
+		},
+		{
+			Name:     "enum_global_init.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_global_init.c",
+			ExitCode: 25,
+		},
+		{
+			Name:     "enum_negative.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_negative.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "enum_trailing_comma.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_trailing_comma.c",
+			ExitCode: 1,
+		},
+		{
+			Name:     "enum_in_struct.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_in_struct.c",
+			ExitCode: 4,
+		},
+		{
+			Name:     "enum_shadow_later.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_shadow_later.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "enum_shadow_nested.c",
+			SrcPath:  "../testdata/stage_21/valid/enum_shadow_nested.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "typedef.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef.c",
+			ExitCode: 12,
+		},
+		{
+			Name:     "typedef_pointer.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_pointer.c",
+			ExitCode: 42,
+		},
+		{
+			Name:     "typedef_struct.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_struct.c",
+			ExitCode: 85,
+		},
+		{
+			Name:     "typedef_array.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_array.c",
+			ExitCode: 15,
+		},
+		{
+			Name:     "typedef_cast.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_cast.c",
+			ExitCode: 21,
+		},
+		{
+			Name:     "typedef_shadow.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_shadow.c",
+			ExitCode: 11,
+		},
+		{
+			Name:     "typedef_shadow_for.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_shadow_for.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "typedef_shadow_param.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_shadow_param.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "typedef_block.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_block.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "typedef_chain.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_chain.c",
+			ExitCode: 12,
+		},
+		{
+			Name:     "typedef_linked_list.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_linked_list.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "typedef_redeclare.c",
+			SrcPath:  "../testdata/stage_22/valid/typedef_redeclare.c",
+			ExitCode: 1,
+		},
+		{
+			Name:     "char.c",
+			SrcPath:  "../testdata/stage_23/valid/char.c",
+			ExitCode: 46,
+		},
+		{
+			Name:     "short.c",
+			SrcPath:  "../testdata/stage_23/valid/short.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "specifiers.c",
+			SrcPath:  "../testdata/stage_23/valid/specifiers.c",
+			ExitCode: 36,
+		},
+		{
+			Name:     "unsigned_compare.c",
+			SrcPath:  "../testdata/stage_23/valid/unsigned_compare.c",
+			ExitCode: 11,
+		},
+		{
+			Name:     "unsigned_div.c",
+			SrcPath:  "../testdata/stage_23/valid/unsigned_div.c",
+			ExitCode: 31,
+		},
+		{
+			Name:     "unsigned_shift.c",
+			SrcPath:  "../testdata/stage_23/valid/unsigned_shift.c",
+			ExitCode: 24,
+		},
+		{
+			Name:     "bool.c",
+			SrcPath:  "../testdata/stage_23/valid/bool.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "promote.c",
+			SrcPath:  "../testdata/stage_23/valid/promote.c",
+			ExitCode: 51,
+		},
+		{
+			Name:     "char_struct.c",
+			SrcPath:  "../testdata/stage_23/valid/char_struct.c",
+			ExitCode: 74,
+		},
+		{
+			Name:     "char_global.c",
+			SrcPath:  "../testdata/stage_23/valid/char_global.c",
+			ExitCode: 49,
+		},
+		{
+			Name:     "char_param.c",
+			SrcPath:  "../testdata/stage_23/valid/char_param.c",
+			ExitCode: 50,
+		},
+		{
+			Name:     "char_pointer.c",
+			SrcPath:  "../testdata/stage_23/valid/char_pointer.c",
+			ExitCode: 30,
+		},
+		{
+			Name:     "void_func.c",
+			SrcPath:  "../testdata/stage_24/valid/void_func.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "void_return.c",
+			SrcPath:  "../testdata/stage_24/valid/void_return.c",
+			ExitCode: 12,
+		},
+		{
+			Name:     "void_prototype.c",
+			SrcPath:  "../testdata/stage_24/valid/void_prototype.c",
+			ExitCode: 11,
+		},
+		{
+			Name:     "void_cast.c",
+			SrcPath:  "../testdata/stage_24/valid/void_cast.c",
+			ExitCode: 4,
+		},
+		{
+			Name:     "void_pointer.c",
+			SrcPath:  "../testdata/stage_24/valid/void_pointer.c",
+			ExitCode: 17,
+		},
+		{
+			Name:    "void_putchar.c",
+			SrcPath: "../testdata/stage_24/valid/void_putchar.c",
+			Ouput:   "H\ni\n",
+		},
+		{
+			Name:     "switch_sparse.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_sparse.c",
+			ExitCode: 100,
+		},
+		{
+			Name:     "switch_dense.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_dense.c",
+			ExitCode: 65,
+		},
+		{
+			Name:     "switch_fallthrough.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_fallthrough.c",
+			ExitCode: 14,
+		},
+		{
+			Name:     "switch_no_default.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_no_default.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "switch_continue.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_continue.c",
+			ExitCode: 48,
+		},
+		{
+			Name:     "switch_nested.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_nested.c",
+			ExitCode: 95,
+		},
+		{
+			Name:     "switch_enum.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_enum.c",
+			ExitCode: 32,
+		},
+		{
+			Name:     "switch_locals.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_locals.c",
+			ExitCode: 62,
+		},
+		{
+			Name:     "switch_char.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_char.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "switch_loop_in_case.c",
+			SrcPath:  "../testdata/stage_25/valid/switch_loop_in_case.c",
+			ExitCode: 14,
+		},
+		{
+			Name:     "case_in_block.c",
+			SrcPath:  "../testdata/stage_25/valid/case_in_block.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "goto_forward.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_forward.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "goto_loop.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_loop.c",
+			ExitCode: 45,
+		},
+		{
+			Name:     "goto_nested_break.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_nested_break.c",
+			ExitCode: 67,
+		},
+		{
+			Name:     "goto_same_name.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_same_name.c",
+			ExitCode: 20,
+		},
+		{
+			Name:     "goto_in_switch.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_in_switch.c",
+			ExitCode: 9,
+		},
+		{
+			Name:     "goto_unused_label.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_unused_label.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "goto_into_block.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_into_block.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "goto_out_of_block.c",
+			SrcPath:  "../testdata/stage_26/valid/goto_out_of_block.c",
+			ExitCode: 160,
+		},
+		{
+			Name:     "comma.c",
+			SrcPath:  "../testdata/stage_27/valid/comma.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "comma_for.c",
+			SrcPath:  "../testdata/stage_27/valid/comma_for.c",
+			ExitCode: 30,
+		},
+		{
+			Name:     "comma_call_args.c",
+			SrcPath:  "../testdata/stage_27/valid/comma_call_args.c",
+			ExitCode: 40,
+		},
+		{
+			Name:     "comma_void.c",
+			SrcPath:  "../testdata/stage_27/valid/comma_void.c",
+			ExitCode: 4,
+		},
+		{
+			Name:     "multi_decl.c",
+			SrcPath:  "../testdata/stage_27/valid/multi_decl.c",
+			ExitCode: 22,
+		},
+		{
+			Name:     "multi_decl_for.c",
+			SrcPath:  "../testdata/stage_27/valid/multi_decl_for.c",
+			ExitCode: 46,
+		},
+		{
+			Name:     "multi_decl_global.c",
+			SrcPath:  "../testdata/stage_27/valid/multi_decl_global.c",
+			ExitCode: 15,
+		},
+		{
+			Name:     "multi_decl_init_order.c",
+			SrcPath:  "../testdata/stage_27/valid/multi_decl_init_order.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "multi_typedef.c",
+			SrcPath:  "../testdata/stage_27/valid/multi_typedef.c",
+			ExitCode: 14,
+		},
+		{
+			Name:     "sizeof_types.c",
+			SrcPath:  "../testdata/stage_28/valid/sizeof_types.c",
+			ExitCode: 146,
+		},
+		{
+			Name:     "sizeof_expr.c",
+			SrcPath:  "../testdata/stage_28/valid/sizeof_expr.c",
+			ExitCode: 63,
+		},
+		{
+			Name:     "sizeof_not_evaluated.c",
+			SrcPath:  "../testdata/stage_28/valid/sizeof_not_evaluated.c",
+			ExitCode: 18,
+		},
+		{
+			Name:     "sizeof_array_length.c",
+			SrcPath:  "../testdata/stage_28/valid/sizeof_array_length.c",
+			ExitCode: 11,
+		},
+		{
+			Name:     "sizeof_unsigned.c",
+			SrcPath:  "../testdata/stage_28/valid/sizeof_unsigned.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "sizeof_struct_elems.c",
+			SrcPath:  "../testdata/stage_28/valid/sizeof_struct_elems.c",
+			ExitCode: 44,
+		},
+		{
+			Name:     "sizeof_malloc.c",
+			SrcPath:  "../testdata/stage_28/valid/sizeof_malloc.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "cast_truncate.c",
+			SrcPath:  "../testdata/stage_28/valid/cast_truncate.c",
+			ExitCode: 76,
+		},
+		{
+			Name:     "cast_extend.c",
+			SrcPath:  "../testdata/stage_28/valid/cast_extend.c",
+			ExitCode: 248,
+		},
+		{
+			Name:     "cast_bool.c",
+			SrcPath:  "../testdata/stage_28/valid/cast_bool.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "cast_same_size.c",
+			SrcPath:  "../testdata/stage_28/valid/cast_same_size.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "funcptr.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "funcptr_address.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_address.c",
+			ExitCode: 30,
+		},
+		{
+			Name:     "funcptr_param.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_param.c",
+			ExitCode: 23,
+		},
+		{
+			Name:     "funcptr_table.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_table.c",
+			ExitCode: 30,
+		},
+		{
+			Name:     "funcptr_struct.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_struct.c",
+			ExitCode: 42,
+		},
+		{
+			Name:     "funcptr_typedef.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_typedef.c",
+			ExitCode: 92,
+		},
+		{
+			Name:     "funcptr_compare.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_compare.c",
+			ExitCode: 47,
+		},
+		{
+			Name:     "funcptr_void.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_void.c",
+			ExitCode: 10,
+		},
+		{
+			Name:     "funcptr_null.c",
+			SrcPath:  "../testdata/stage_29/valid/funcptr_null.c",
+			ExitCode: 10,
+		},
+		{
+			Name:     "static_local.c",
+			SrcPath:  "../testdata/stage_30/valid/static_local.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "static_local_init.c",
+			SrcPath:  "../testdata/stage_30/valid/static_local_init.c",
+			ExitCode: 20,
+		},
+		{
+			Name:     "static_local_same_name.c",
+			SrcPath:  "../testdata/stage_30/valid/static_local_same_name.c",
+			ExitCode: 104,
+		},
+		{
+			Name:     "static_local_address.c",
+			SrcPath:  "../testdata/stage_30/valid/static_local_address.c",
+			ExitCode: 10,
+		},
+		{
+			Name:     "static_local_array.c",
+			SrcPath:  "../testdata/stage_30/valid/static_local_array.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "static_global.c",
+			SrcPath:  "../testdata/stage_30/valid/static_global.c",
+			ExitCode: 9,
+		},
+		{
+			Name:     "static_prototype.c",
+			SrcPath:  "../testdata/stage_30/valid/static_prototype.c",
+			ExitCode: 42,
+		},
+		{
+			Name:     "static_main.c",
+			SrcPath:  "../testdata/stage_30/valid/static_main.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "extern_global.c",
+			SrcPath:  "../testdata/stage_30/valid/extern_global.c",
+			ExitCode: 42,
+		},
+		{
+			Name:     "extern_function.c",
+			SrcPath:  "../testdata/stage_30/valid/extern_function.c",
+			Ouput:    "extern\n",
+			ExitCode: 49,
+		},
+		{
+			Name:     "extern_block.c",
+			SrcPath:  "../testdata/stage_30/valid/extern_block.c",
+			ExitCode: 14,
+		},
+		{
+			Name:     "extern_static.c",
+			SrcPath:  "../testdata/stage_30/valid/extern_static.c",
+			ExitCode: 4,
+		},
+		{
+			Name:     "extern_only.c",
+			SrcPath:  "../testdata/stage_30/valid/extern_only.c",
+			ExitCode: 3,
+		},
+		{
+			Name:     "extern_initialized.c",
+			SrcPath:  "../testdata/stage_30/valid/extern_initialized.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "const_local.c",
+			SrcPath:  "../testdata/stage_31/valid/const_local.c",
+			ExitCode: 10,
+		},
+		{
+			Name:     "const_pointer.c",
+			SrcPath:  "../testdata/stage_31/valid/const_pointer.c",
+			ExitCode: 9,
+		},
+		{
+			Name:     "const_global.c",
+			SrcPath:  "../testdata/stage_31/valid/const_global.c",
+			ExitCode: 110,
+		},
+		{
+			Name:     "const_struct.c",
+			SrcPath:  "../testdata/stage_31/valid/const_struct.c",
+			ExitCode: 17,
+		},
+		{
+			Name:     "const_param.c",
+			SrcPath:  "../testdata/stage_31/valid/const_param.c",
+			ExitCode: 15,
+		},
+		{
+			Name:     "const_qualifier_order.c",
+			SrcPath:  "../testdata/stage_31/valid/const_qualifier_order.c",
+			ExitCode: 134,
+		},
+		{
+			Name:     "const_typedef.c",
+			SrcPath:  "../testdata/stage_31/valid/const_typedef.c",
+			ExitCode: 7,
+		},
+		{
+			Name:     "volatile.c",
+			SrcPath:  "../testdata/stage_31/valid/volatile.c",
+			ExitCode: 12,
+		},
+		{
+			Name:     "printf.c",
+			SrcPath:  "../testdata/stage_32/valid/printf.c",
+			Ouput:    "42 x -3 4000000000 str\ndone\n",
+			ExitCode: 7,
+		},
+		{
+			Name:     "variadic_sum.c",
+			SrcPath:  "../testdata/stage_32/valid/variadic_sum.c",
+			ExitCode: 12,
+		},
+		{
+			Name:     "variadic_promotion.c",
+			SrcPath:  "../testdata/stage_32/valid/variadic_promotion.c",
+			ExitCode: 169,
+		},
+		{
+			Name:     "variadic_types.c",
+			SrcPath:  "../testdata/stage_32/valid/variadic_types.c",
+			ExitCode: 116,
+		},
+		{
+			Name:     "variadic_forward.c",
+			SrcPath:  "../testdata/stage_32/valid/variadic_forward.c",
+			ExitCode: 27,
+		},
+		{
+			Name:     "variadic_pointer.c",
+			SrcPath:  "../testdata/stage_32/valid/variadic_pointer.c",
+			ExitCode: 43,
+		},
+		{
+			Name:     "variadic_prototype.c",
+			SrcPath:  "../testdata/stage_32/valid/variadic_prototype.c",
+			ExitCode: 30,
+		},
+		{
+			Name:     "designated_struct.c",
+			SrcPath:  "../testdata/stage_33/valid/designated_struct.c",
+			ExitCode: 71,
+		},
+		{
+			Name:     "designated_array.c",
+			SrcPath:  "../testdata/stage_33/valid/designated_array.c",
+			ExitCode: 194,
+		},
+		{
+			Name:     "designated_nested.c",
+			SrcPath:  "../testdata/stage_33/valid/designated_nested.c",
+			ExitCode: 5,
+		},
+		{
+			Name:     "designated_global.c",
+			SrcPath:  "../testdata/stage_33/valid/designated_global.c",
+			ExitCode: 25,
+		},
+		{
+			Name:     "designated_union.c",
+			SrcPath:  "../testdata/stage_33/valid/designated_union.c",
+			ExitCode: 8,
+		},
+		{
+			Name:     "designated_override.c",
+			SrcPath:  "../testdata/stage_33/valid/designated_override.c",
+			ExitCode: 64,
+		},
+		{
+			Name:     "brace_elision.c",
+			SrcPath:  "../testdata/stage_33/valid/brace_elision.c",
+			ExitCode: 128,
+		},
+		{
+			Name:     "zero_fill.c",
+			SrcPath:  "../testdata/stage_33/valid/zero_fill.c",
+			ExitCode: 9,
+		},
+		{
+			Name:     "string_init.c",
+			SrcPath:  "../testdata/stage_33/valid/string_init.c",
+			Ouput:    "hello\nabc\nbob\n",
+			ExitCode: 9,
+		},
+		{
+			Name:     "compound_literal.c",
+			SrcPath:  "../testdata/stage_33/valid/compound_literal.c",
+			ExitCode: 51,
+		},
+		{
+			Name:     "compound_literal_loop.c",
+			SrcPath:  "../testdata/stage_33/valid/compound_literal_loop.c",
+			ExitCode: 6,
+		},
+		{
+			Name:     "compound_literal_global.c",
+			SrcPath:  "../testdata/stage_33/valid/compound_literal_global.c",
+			ExitCode: 24,
+		},
+		{
+			Name:     "address_constant.c",
+			SrcPath:  "../testdata/stage_33/valid/address_constant.c",
+			ExitCode: 33,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
@@ -163,6 +1238,36 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		SrcPath  string
+		Warnings []string
+	}{
+		{
+			SrcPath:  "../testdata/stage_26/valid/goto_unused_label.c",
+			Warnings: []string{"2:2: label unused defined but not used"},
+		},
+		{
+			SrcPath: "../testdata/stage_26/valid/goto_same_name.c",
+		},
+		{
+			SrcPath:  "../testdata/stage_30/valid/extern_initialized.c",
+			Warnings: []string{"1:1: value initialized and declared extern"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.SrcPath), func(t *testing.T) {
+			src, err := ioutil.ReadFile(tt.SrcPath)
+			assert.NilError(t, err)
+			prog, err := parser.Parse(string(src))
+			assert.NilError(t, err)
+			c := New()
+			assert.NilError(t, c.Compile(prog))
+			assert.DeepEqual(t, c.Warnings(), tt.Warnings)
+		})
+	}
+}
+
 func TestStages(t *testing.T) {
 	AssertValid(t, 1)
 	AssertValid(t, 2)
@@ -173,6 +1278,52 @@ func TestStages(t *testing.T) {
 	AssertValid(t, 7)
 	AssertValid(t, 8)
 	AssertValid(t, 9)
+	AssertValid(t, 10)
+	AssertValid(t, 11)
+	AssertInvalid(t, 11)
+	AssertValid(t, 12)
+	AssertInvalid(t, 12)
+	AssertValid(t, 13)
+	AssertInvalid(t, 13)
+	AssertValid(t, 14)
+	AssertInvalid(t, 14)
+	AssertValid(t, 15)
+	AssertInvalid(t, 15)
+	AssertValid(t, 16)
+	AssertValid(t, 17)
+	AssertInvalid(t, 17)
+	AssertValid(t, 18)
+	AssertInvalid(t, 18)
+	AssertValid(t, 19)
+	AssertInvalid(t, 19)
+	AssertValid(t, 20)
+	AssertInvalid(t, 20)
+	AssertValid(t, 21)
+	AssertInvalid(t, 21)
+	AssertValid(t, 22)
+	AssertInvalid(t, 22)
+	AssertValid(t, 23)
+	AssertInvalid(t, 23)
+	AssertValid(t, 24)
+	AssertInvalid(t, 24)
+	AssertValid(t, 25)
+	AssertInvalid(t, 25)
+	AssertValid(t, 26)
+	AssertInvalid(t, 26)
+	AssertValid(t, 27)
+	AssertInvalid(t, 27)
+	AssertValid(t, 28)
+	AssertInvalid(t, 28)
+	AssertValid(t, 29)
+	AssertInvalid(t, 29)
+	AssertValid(t, 30)
+	AssertInvalid(t, 30)
+	AssertValid(t, 31)
+	AssertInvalid(t, 31)
+	AssertValid(t, 32)
+	AssertInvalid(t, 32)
+	AssertValid(t, 33)
+	AssertInvalid(t, 33)
 }
 
 func AssertValid(t *testing.T, stage int) {
@@ -190,6 +1341,22 @@ func AssertValid(t *testing.T, stage int) {
 	})
 }
 
+func AssertInvalid(t *testing.T, stage int) {
+	t.Run(fmt.Sprintf("stage_%d", stage), func(t *testing.T) {
+		pattern := fmt.Sprintf("../testdata/stage_%d/invalid/*.c", stage)
+		invalid, err := filepath.Glob(pattern)
+		assert.NilError(t, err)
+		for _, srcpath := range invalid {
+			t.Run(filepath.Base(srcpath), func(t *testing.T) {
+				src, err := ioutil.ReadFile(srcpath)
+				assert.NilError(t, err)
+				_, err = Compile(string(src))
+				assert.Assert(t, err != nil)
+			})
+		}
+	})
+}
+
 const binName = "out.exe"
 
 func assertRun(t *testing.T, dir *fs.Dir) (string, int) {
@@ -12,6 +12,7 @@ type Lexer struct {
 	index int
 	ch    byte
 	pos   token.Pos
+	prev  token.Pos
 }
 
 func New(input string) *Lexer {
@@ -34,11 +35,14 @@ func (l *Lexer) peek() byte {
 func (l *Lexer) unread() {
 	l.index--
 	l.ch = l.input[l.index]
+	l.pos = l.prev
 }
 
 func (l *Lexer) read() byte {
+	l.prev = l.pos
 	// make sure there's more
 	if l.index+1 >= len(l.input) {
+		l.index = len(l.input)
 		l.ch = 0
 		return 0
 	}
@@ -89,6 +93,12 @@ var bytetokens = map[byte]token.TokenType{
 	':': token.COLON,
 	'%': token.PERCENT,
 	',': token.COMMA,
+	'&': token.AMPERSAND,
+	'|': token.PIPE,
+	'^': token.CARET,
+	'.': token.DOT,
+	'[': token.LBRACKET,
+	']': token.RBRACKET,
 }
 
 var twobytetokens = map[string]token.TokenType{
@@ -98,12 +108,33 @@ var twobytetokens = map[string]token.TokenType{
 	"!=": token.NE,
 	"<=": token.LT_EQ,
 	">=": token.GT_EQ,
+	"<<": token.SHL,
+	">>": token.SHR,
+	"++": token.INC,
+	"--": token.DEC,
+	"+=": token.PLUS_EQ,
+	"-=": token.MINUS_EQ,
+	"*=": token.ASTERISK_EQ,
+	"/=": token.SLASH_EQ,
+	"%=": token.PERCENT_EQ,
+	"&=": token.AMPERSAND_EQ,
+	"|=": token.PIPE_EQ,
+	"^=": token.CARET_EQ,
+	"->": token.ARROW,
+}
+
+var threebytetokens = map[string]token.TokenType{
+	"<<=": token.SHL_EQ,
+	">>=": token.SHR_EQ,
+	"...": token.ELLIPSIS,
 }
 
 func (l *Lexer) Lex() token.Token {
 	// find the next non-white token
 	l.read()
-	l.whitespace()
+	if tok, ok := l.whitespace(); !ok {
+		return tok
+	}
 	pos := l.pos
 
 	// check for end of file
@@ -111,6 +142,14 @@ func (l *Lexer) Lex() token.Token {
 		return l.newTok(token.EOF, "", pos)
 	}
 
+	// triple byte tokens
+	threebytes := l.threeBytes()
+	if typ, ok := threebytetokens[threebytes]; ok {
+		l.read()
+		l.read()
+		return l.newTok(typ, threebytes, pos)
+	}
+
 	// double byte tokens
 	twobytes := l.twoBytes()
 	if typ, ok := twobytetokens[twobytes]; ok {
@@ -127,6 +166,10 @@ func (l *Lexer) Lex() token.Token {
 	switch {
 	case l.isDigit():
 		return l.lexInt()
+	case l.ch == '\'':
+		return l.lexChar()
+	case l.ch == '"':
+		return l.lexString()
 	case l.isAlpha():
 		tok := l.lexIdent()
 		if typ, ok := token.Keywords[tok.Text]; ok {
@@ -134,7 +177,7 @@ func (l *Lexer) Lex() token.Token {
 		}
 		return tok
 	default:
-		return l.newByteTok(token.ILLEGAL, pos)
+		return l.illegal(pos, "unexpected character: %q", l.ch)
 	}
 }
 
@@ -142,6 +185,14 @@ func (l *Lexer) twoBytes() string {
 	return string([]byte{l.ch, l.peek()})
 }
 
+func (l *Lexer) threeBytes() string {
+	next := l.index + 2
+	if next >= len(l.input) {
+		return ""
+	}
+	return string([]byte{l.ch, l.peek(), l.input[next]})
+}
+
 func (l *Lexer) Tokenize() []token.Token {
 	var toks []token.Token
 	for {
@@ -157,12 +208,175 @@ func (l *Lexer) Tokenize() []token.Token {
 func (l *Lexer) lexInt() token.Token {
 	pos := l.pos
 	var text strings.Builder
-	for l.isDigit() {
+	for l.isDigit() || l.isAlpha() {
 		text.WriteByte(l.ch)
 		l.read()
 	}
 	l.unread()
-	return l.newTok(token.INT_LIT, text.String(), pos)
+	tok := l.newTok(token.INT_LIT, text.String(), pos)
+	// radix prefix
+	digits := tok.Text
+	switch {
+	case len(digits) > 1 && digits[0] == '0' && (digits[1] == 'x' || digits[1] == 'X'):
+		tok.Radix, digits = 16, digits[2:]
+	case len(digits) > 1 && digits[0] == '0' && (digits[1] == 'b' || digits[1] == 'B'):
+		tok.Radix, digits = 2, digits[2:]
+	case digits[0] == '0':
+		tok.Radix = 8
+	default:
+		tok.Radix = 10
+	}
+	// digits
+	n := 0
+	for n < len(digits) && isRadixDigit(digits[n], tok.Radix) {
+		n++
+	}
+	if n == 0 {
+		return l.illegal(pos, "invalid integer constant: %s", tok.Text)
+	}
+	if n < len(digits) && isRadixDigit(digits[n], 10) {
+		return l.illegal(pos, "invalid digit %q in base %d constant: %s", digits[n], tok.Radix, tok.Text)
+	}
+	// suffix
+	tok.Suffix = digits[n:]
+	if !isIntSuffix(tok.Suffix) {
+		return l.illegal(pos, "invalid suffix %q on integer constant: %s", tok.Suffix, tok.Text)
+	}
+	return tok
+}
+
+func isRadixDigit(ch byte, radix int) bool {
+	v := digitValue(ch)
+	return v >= 0 && v < radix
+}
+
+func digitValue(ch byte) int {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return int(ch - '0')
+	case 'a' <= ch && ch <= 'f':
+		return int(ch-'a') + 10
+	case 'A' <= ch && ch <= 'F':
+		return int(ch-'A') + 10
+	default:
+		return -1
+	}
+}
+
+func isIntSuffix(s string) bool {
+	switch s {
+	case "", "u", "U",
+		"l", "L", "ll", "LL",
+		"ul", "uL", "Ul", "UL",
+		"lu", "lU", "Lu", "LU",
+		"ull", "uLL", "Ull", "ULL",
+		"llu", "llU", "LLu", "LLU":
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *Lexer) lexChar() token.Token {
+	pos := l.pos
+	var value []byte
+	l.read()
+	for l.ch != '\'' {
+		if l.ch == 0 || l.ch == '\n' || l.ch == '\r' {
+			return l.illegal(pos, "unterminated character constant")
+		}
+		ch, tok, ok := l.escape()
+		if !ok {
+			return tok
+		}
+		value = append(value, ch)
+		l.read()
+	}
+	tok := l.newTok(token.CHAR_LIT, l.input[pos.Offset:l.index+1], pos)
+	switch len(value) {
+	case 0:
+		return l.illegal(pos, "empty character constant")
+	case 1:
+		tok.Value = string(value)
+		return tok
+	default:
+		return l.illegal(pos, "multi-character character constant: %s", tok.Text)
+	}
+}
+
+func (l *Lexer) lexString() token.Token {
+	pos := l.pos
+	var value []byte
+	l.read()
+	for l.ch != '"' {
+		if l.ch == 0 || l.ch == '\n' || l.ch == '\r' {
+			return l.illegal(pos, "unterminated string literal")
+		}
+		ch, tok, ok := l.escape()
+		if !ok {
+			return tok
+		}
+		value = append(value, ch)
+		l.read()
+	}
+	tok := l.newTok(token.STRING, l.input[pos.Offset:l.index+1], pos)
+	tok.Value = string(value)
+	return tok
+}
+
+var escapes = map[byte]byte{
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+	'a':  '\a',
+	'b':  '\b',
+	'f':  '\f',
+	'v':  '\v',
+	'\\': '\\',
+	'\'': '\'',
+	'"':  '"',
+	'?':  '?',
+}
+
+// escape decodes the character at the current position. If it's the start of
+// an escape sequence, the whole sequence is consumed and the current character
+// is left on its last byte.
+func (l *Lexer) escape() (ch byte, tok token.Token, ok bool) {
+	if l.ch != '\\' {
+		return l.ch, token.Token{}, true
+	}
+	pos := l.pos
+	l.read()
+	if ch, ok := escapes[l.ch]; ok {
+		return ch, token.Token{}, true
+	}
+	switch {
+	case l.ch == 'x':
+		if !isRadixDigit(l.peek(), 16) {
+			return 0, l.illegal(pos, "\\x used with no following hex digits"), false
+		}
+		value := 0
+		for isRadixDigit(l.peek(), 16) {
+			value = value*16 + digitValue(l.read())
+			if value > 0xFF {
+				return 0, l.illegal(pos, "hex escape sequence out of range"), false
+			}
+		}
+		return byte(value), token.Token{}, true
+	case isRadixDigit(l.ch, 8):
+		value := digitValue(l.ch)
+		for i := 0; i < 2 && isRadixDigit(l.peek(), 8); i++ {
+			value = value*8 + digitValue(l.read())
+		}
+		if value > 0xFF {
+			return 0, l.illegal(pos, "octal escape sequence out of range"), false
+		}
+		return byte(value), token.Token{}, true
+	case l.ch == 0:
+		return 0, l.illegal(pos, "unterminated escape sequence"), false
+	default:
+		return 0, l.illegal(pos, "unknown escape sequence: \\%c", l.ch), false
+	}
 }
 
 func (l *Lexer) lexIdent() token.Token {
@@ -176,9 +390,36 @@ func (l *Lexer) lexIdent() token.Token {
 	return l.newTok(token.IDENT, text.String(), pos)
 }
 
-func (l *Lexer) whitespace() {
-	for l.isWhite() {
-		l.read()
+func (l *Lexer) illegal(pos token.Pos, format string, args ...interface{}) token.Token {
+	return l.newTok(token.ILLEGAL, fmt.Sprintf(format, args...), pos)
+}
+
+// whitespace skips over whitespace and comments. If an unterminated
+// block comment is found, an ILLEGAL token is returned with ok set to false.
+func (l *Lexer) whitespace() (tok token.Token, ok bool) {
+	for {
+		switch {
+		case l.isWhite():
+			l.read()
+		case l.ch == '/' && l.peek() == '/':
+			for l.ch != '\n' && l.ch != '\r' && l.ch != 0 {
+				l.read()
+			}
+		case l.ch == '/' && l.peek() == '*':
+			pos := l.pos
+			l.read()
+			l.read()
+			for l.ch != '*' || l.peek() != '/' {
+				if l.ch == 0 {
+					return l.illegal(pos, "unterminated comment"), false
+				}
+				l.read()
+			}
+			l.read()
+			l.read()
+		default:
+			return token.Token{}, true
+		}
 	}
 }
 
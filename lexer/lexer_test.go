@@ -54,58 +54,189 @@ func withRetval(stage int, name string, retval ...token.Token) lexerTest {
 	return tt
 }
 
+func intLit(text string, radix int, suffix string) token.Token {
+	tok := token.New(token.INT_LIT, text)
+	tok.Radix = radix
+	tok.Suffix = suffix
+	return tok
+}
+
+func charLit(text string, value byte) token.Token {
+	tok := token.New(token.CHAR_LIT, text)
+	tok.Value = string([]byte{value})
+	return tok
+}
+
+func stringLit(text, value string) token.Token {
+	tok := token.New(token.STRING, text)
+	tok.Value = value
+	return tok
+}
+
 func TestLexer(t *testing.T) {
 	tests := []lexerTest{
-		withRetval(1, "multi_digit.c", token.New(token.INT_LIT, "100")),
-		withRetval(1, "newlines.c", token.New(token.INT_LIT, "0")),
-		withRetval(1, "return_2.c", token.New(token.INT_LIT, "2")),
-		withRetval(1, "no_newlines.c", token.New(token.INT_LIT, "0")),
-		withRetval(1, "return_0.c", token.New(token.INT_LIT, "0")),
-		withRetval(1, "spaces.c", token.New(token.INT_LIT, "0")),
+		withRetval(1, "multi_digit.c", intLit("100", 10, "")),
+		withRetval(1, "newlines.c", intLit("0", 8, "")),
+		withRetval(1, "return_2.c", intLit("2", 10, "")),
+		withRetval(1, "no_newlines.c", intLit("0", 8, "")),
+		withRetval(1, "return_0.c", intLit("0", 8, "")),
+		withRetval(1, "spaces.c", intLit("0", 8, "")),
 		withRetval(2, "bitwise.c",
 			token.New(token.BANG, "!"),
-			token.New(token.INT_LIT, "12"),
+			intLit("12", 10, ""),
 		),
 		withRetval(2, "bitwise_zero.c",
 			token.New(token.TILDA, "~"),
-			token.New(token.INT_LIT, "0"),
+			intLit("0", 8, ""),
 		),
 		withRetval(2, "bitwise_zero.c",
 			token.New(token.TILDA, "~"),
-			token.New(token.INT_LIT, "0"),
+			intLit("0", 8, ""),
 		),
 		withRetval(2, "neg.c",
 			token.New(token.MINUS, "-"),
-			token.New(token.INT_LIT, "5"),
+			intLit("5", 10, ""),
 		),
 		withRetval(2, "nested_ops.c",
 			token.New(token.BANG, "!"),
 			token.New(token.MINUS, "-"),
-			token.New(token.INT_LIT, "3"),
+			intLit("3", 10, ""),
 		),
 		withRetval(3, "add.c",
-			token.New(token.INT_LIT, "1"),
+			intLit("1", 10, ""),
 			token.New(token.PLUS, "+"),
-			token.New(token.INT_LIT, "2"),
+			intLit("2", 10, ""),
 		),
 		withRetval(3, "precedence.c",
-			token.New(token.INT_LIT, "2"),
+			intLit("2", 10, ""),
 			token.New(token.PLUS, "+"),
-			token.New(token.INT_LIT, "3"),
+			intLit("3", 10, ""),
 			token.New(token.ASTERISK, "*"),
-			token.New(token.INT_LIT, "4"),
+			intLit("4", 10, ""),
 		),
 		withRetval(4, "and_false.c",
-			token.New(token.INT_LIT, "1"),
+			intLit("1", 10, ""),
 			token.New(token.AND, "&&"),
-			token.New(token.INT_LIT, "0"),
+			intLit("0", 8, ""),
 		),
 		withRetval(4, "and_true.c",
-			token.New(token.INT_LIT, "1"),
+			intLit("1", 10, ""),
 			token.New(token.AND, "&&"),
 			token.New(token.MINUS, "-"),
-			token.New(token.INT_LIT, "1"),
+			intLit("1", 10, ""),
+		),
+		withRetval(10, "line_comment.c", intLit("2", 10, "")),
+		withRetval(10, "block_comment.c",
+			intLit("1", 10, ""),
+			token.New(token.PLUS, "+"),
+			intLit("2", 10, ""),
 		),
+		withRetval(10, "comment_slash.c",
+			intLit("6", 10, ""),
+			token.New(token.SLASH, "/"),
+			intLit("2", 10, ""),
+		),
+		withRetval(10, "comment_eof.c", intLit("0", 8, "")),
+		withRetval(11, "hex.c", intLit("0x1F", 16, "")),
+		withRetval(11, "hex_upper.c",
+			intLit("0Xa", 16, ""),
+			token.New(token.PLUS, "+"),
+			intLit("0XB", 16, ""),
+		),
+		withRetval(11, "octal.c", intLit("017", 8, "")),
+		withRetval(11, "binary.c", intLit("0b101", 2, "")),
+		withRetval(11, "unsigned.c",
+			intLit("10u", 10, "u"),
+			token.New(token.PLUS, "+"),
+			intLit("20U", 10, "U"),
+		),
+		withRetval(11, "unsigned_long.c",
+			intLit("7UL", 10, "UL"),
+			token.New(token.PLUS, "+"),
+			intLit("1lu", 10, "lu"),
+		),
+		withRetval(12, "escapes.c",
+			charLit(`'\n'`, '\n'),
+			token.New(token.PLUS, "+"),
+			charLit(`'\t'`, '\t'),
+			token.New(token.PLUS, "+"),
+			charLit(`'\\'`, '\\'),
+			token.New(token.PLUS, "+"),
+			charLit(`'\''`, '\''),
+			token.New(token.PLUS, "+"),
+			charLit(`'\"'`, '"'),
+			token.New(token.PLUS, "+"),
+			charLit(`'\?'`, '?'),
+			token.New(token.PLUS, "+"),
+			charLit(`'\0'`, 0),
+		),
+		withRetval(12, "hex_escape.c", charLit(`'\x41'`, 'A')),
+		withRetval(12, "octal_escape.c",
+			charLit(`'\101'`, 'A'),
+			token.New(token.MINUS, "-"),
+			charLit(`'\7'`, 7),
+		),
+		withRetval(12, "char_arith.c",
+			charLit("'7'", '7'),
+			token.New(token.MINUS, "-"),
+			charLit("'0'", '0'),
+		),
+	}
+	for _, tt := range tests {
+		t.Run(tt.SrcPath, tt.Run)
+	}
+}
+
+func TestLexerString(t *testing.T) {
+	tests := []lexerTest{
+		{
+			SrcPath: "../testdata/stage_13/valid/concat.c",
+			Expected: []token.Token{
+				token.New(token.INT_TYPE, "int"),
+				token.New(token.IDENT, "puts"),
+				token.New(token.LPAREN, "("),
+				token.New(token.CHAR_TYPE, "char"),
+				token.New(token.ASTERISK, "*"),
+				token.New(token.IDENT, "s"),
+				token.New(token.RPAREN, ")"),
+				token.New(token.SEMICOLON, ";"),
+				token.New(token.INT_TYPE, "int"),
+				token.New(token.IDENT, "main"),
+				token.New(token.LPAREN, "("),
+				token.New(token.RPAREN, ")"),
+				token.New(token.LBRACE, "{"),
+				token.New(token.IDENT, "puts"),
+				token.New(token.LPAREN, "("),
+				stringLit(`"Hello, "`, "Hello, "),
+				stringLit(`"World"`, "World"),
+				stringLit(`"!"`, "!"),
+				token.New(token.RPAREN, ")"),
+				token.New(token.SEMICOLON, ";"),
+				token.New(token.RBRACE, "}"),
+				token.New(token.EOF, ""),
+			},
+		},
+		{
+			SrcPath: "../testdata/stage_13/valid/empty.c",
+			Expected: []token.Token{
+				token.New(token.INT_TYPE, "int"),
+				token.New(token.IDENT, "puts"),
+				token.New(token.LPAREN, "("),
+				token.New(token.CHAR_TYPE, "char"),
+				token.New(token.ASTERISK, "*"),
+				token.New(token.IDENT, "s"),
+				token.New(token.RPAREN, ")"),
+				token.New(token.SEMICOLON, ";"),
+				token.New(token.INT_TYPE, "int"),
+				token.New(token.IDENT, "main"),
+				token.New(token.LPAREN, "("),
+				token.New(token.RPAREN, ")"),
+				token.New(token.LBRACE, "{"),
+				token.New(token.IDENT, "puts"),
+				token.New(token.LPAREN, "("),
+				stringLit(`""`, ""),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,6 +244,92 @@ func TestLexer(t *testing.T) {
 	}
 }
 
+func TestLexerOperators(t *testing.T) {
+	tt := lexerTest{
+		SrcPath: "../testdata/stage_14/operators.c",
+		Expected: []token.Token{
+			token.New(token.AMPERSAND, "&"),
+			token.New(token.PIPE, "|"),
+			token.New(token.CARET, "^"),
+			token.New(token.SHL, "<<"),
+			token.New(token.SHR, ">>"),
+			token.New(token.INC, "++"),
+			token.New(token.DEC, "--"),
+			token.New(token.PLUS_EQ, "+="),
+			token.New(token.MINUS_EQ, "-="),
+			token.New(token.ASTERISK_EQ, "*="),
+			token.New(token.SLASH_EQ, "/="),
+			token.New(token.PERCENT_EQ, "%="),
+			token.New(token.AMPERSAND_EQ, "&="),
+			token.New(token.PIPE_EQ, "|="),
+			token.New(token.CARET_EQ, "^="),
+			token.New(token.SHL_EQ, "<<="),
+			token.New(token.SHR_EQ, ">>="),
+			token.New(token.ARROW, "->"),
+			token.New(token.DOT, "."),
+			token.New(token.LBRACKET, "["),
+			token.New(token.RBRACKET, "]"),
+			token.New(token.ELLIPSIS, "..."),
+			token.New(token.AND, "&&"),
+			token.New(token.OR, "||"),
+			token.New(token.EQ, "=="),
+			token.New(token.NE, "!="),
+			token.New(token.LT_EQ, "<="),
+			token.New(token.GT_EQ, ">="),
+			token.New(token.LT, "<"),
+			token.New(token.GT, ">"),
+			token.New(token.ASSIGN, "="),
+			token.New(token.PLUS, "+"),
+			token.New(token.MINUS, "-"),
+			token.New(token.ASTERISK, "*"),
+			token.New(token.SLASH, "/"),
+			token.New(token.PERCENT, "%"),
+			token.New(token.TILDA, "~"),
+			token.New(token.BANG, "!"),
+			token.New(token.QUESTION, "?"),
+			token.New(token.COLON, ":"),
+			token.New(token.COMMA, ","),
+			token.New(token.SEMICOLON, ";"),
+			token.New(token.IDENT, "a"),
+			token.New(token.INC, "++"),
+			token.New(token.INC, "++"),
+			token.New(token.PLUS, "+"),
+			token.New(token.IDENT, "b"),
+			token.New(token.IDENT, "p"),
+			token.New(token.ARROW, "->"),
+			token.New(token.IDENT, "x"),
+			token.New(token.IDENT, "s"),
+			token.New(token.DOT, "."),
+			token.New(token.IDENT, "y"),
+			token.New(token.IDENT, "a"),
+			token.New(token.SHL_EQ, "<<="),
+			token.New(token.IDENT, "b"),
+			token.New(token.SHR_EQ, ">>="),
+			token.New(token.IDENT, "c"),
+			token.New(token.IDENT, "a"),
+			token.New(token.SHL, "<<"),
+			token.New(token.LT, "<"),
+			token.New(token.IDENT, "b"),
+			token.New(token.ELLIPSIS, "..."),
+			token.New(token.DOT, "."),
+			token.New(token.IDENT, "a"),
+			token.New(token.AND, "&&"),
+			token.New(token.AMPERSAND, "&"),
+			token.New(token.IDENT, "b"),
+			token.New(token.IDENT, "a"),
+			token.New(token.OR, "||"),
+			token.New(token.PIPE, "|"),
+			token.New(token.IDENT, "b"),
+			token.New(token.IDENT, "a"),
+			token.New(token.DEC, "--"),
+			token.New(token.MINUS, "-"),
+			token.New(token.IDENT, "b"),
+			token.New(token.EOF, ""),
+		},
+	}
+	tt.Run(t)
+}
+
 func TestLexerPos(t *testing.T) {
 	tests := []struct {
 		file     string
@@ -129,6 +346,31 @@ func TestLexerPos(t *testing.T) {
 			index:    5,
 			expected: token.Pos{Offset: 18, Line: 2, Col: 6},
 		},
+		{
+			file:     "../testdata/stage_10/valid/block_comment.c",
+			index:    5,
+			expected: token.Pos{Offset: 107, Line: 6, Col: 6},
+		},
+		{
+			file:     "../testdata/stage_10/valid/block_comment.c",
+			index:    7,
+			expected: token.Pos{Offset: 126, Line: 6, Col: 25},
+		},
+		{
+			file:     "../testdata/stage_10/invalid/unterminated_comment.c",
+			index:    9,
+			expected: token.Pos{Offset: 29, Line: 4, Col: 2},
+		},
+		{
+			file:     "../testdata/stage_12/invalid/unknown_escape.c",
+			index:    6,
+			expected: token.Pos{Offset: 25, Line: 2, Col: 14},
+		},
+		{
+			file:     "../testdata/stage_12/invalid/multi_char.c",
+			index:    6,
+			expected: token.Pos{Offset: 24, Line: 2, Col: 13},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(filepath.Base(tt.file), func(t *testing.T) {
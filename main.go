@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/icholy/cc/compiler"
+	"github.com/icholy/cc/parser"
 )
 
 func main() {
@@ -28,12 +29,19 @@ func compile(file string) error {
 	if err != nil {
 		return err
 	}
-	asm, err := compiler.Compile(string(src))
+	prog, err := parser.Parse(string(src))
 	if err != nil {
 		return err
 	}
+	c := compiler.New()
+	if err := c.Compile(prog); err != nil {
+		return err
+	}
+	for _, w := range c.Warnings() {
+		log.Printf("%s: warning: %s", file, w)
+	}
 	name := outputName(file)
-	return ioutil.WriteFile(name, []byte(asm), os.ModePerm)
+	return ioutil.WriteFile(name, []byte(c.Assembly()), os.ModePerm)
 }
 
 func outputName(file string) string {
@@ -3,10 +3,12 @@ package parser
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/icholy/cc/ast"
 	"github.com/icholy/cc/lexer"
 	"github.com/icholy/cc/token"
+	"github.com/icholy/cc/types"
 )
 
 type Parser struct {
@@ -14,6 +16,65 @@ type Parser struct {
 	cur   token.Token
 	lex   *lexer.Lexer
 	level int
+	scope *scope
+	enums []ast.Stmt
+}
+
+// scope contains the names declared in a block which the
+// parser needs in order to build types. Struct and union tags
+// map to their type and enum tags map to int. Typedef names map
+// to their type and ordinary identifiers, which may shadow them,
+// map to nil.
+type scope struct {
+	parent *scope
+	tags   map[string]types.Type
+	names  map[string]types.Type
+}
+
+// lookupTypedef returns the type named by a typedef or nil if
+// name isn't a visible typedef name.
+func (s *scope) lookupTypedef(name string) types.Type {
+	if t, ok := s.names[name]; ok {
+		return t
+	}
+	if s.parent != nil {
+		return s.parent.lookupTypedef(name)
+	}
+	return nil
+}
+
+// declare records an ordinary identifier or, when typ is not
+// nil, a typedef name.
+func (s *scope) declare(name string, typ types.Type) error {
+	if prev, ok := s.names[name]; ok && (prev == nil) != (typ == nil) {
+		return fmt.Errorf("%s redeclared as a different kind of symbol", name)
+	} else if ok && prev != nil && !types.Identical(prev, typ) {
+		return fmt.Errorf("conflicting types for typedef %s", name)
+	}
+	s.names[name] = typ
+	return nil
+}
+
+func (s *scope) lookupTag(name string) types.Type {
+	if t, ok := s.tags[name]; ok {
+		return t
+	}
+	if s.parent != nil {
+		return s.parent.lookupTag(name)
+	}
+	return nil
+}
+
+func (p *Parser) enterScope() {
+	p.scope = &scope{
+		parent: p.scope,
+		tags:   make(map[string]types.Type),
+		names:  make(map[string]types.Type),
+	}
+}
+
+func (p *Parser) leaveScope() {
+	p.scope = p.scope.parent
 }
 
 func Parse(input string) (*ast.Program, error) {
@@ -28,6 +89,8 @@ func Parse(input string) (*ast.Program, error) {
 
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{lex: l}
+	p.enterScope()
+	p.scope.declare("va_list", types.VaList)
 	p.next()
 	p.next()
 	return p
@@ -43,6 +106,9 @@ func (p *Parser) next() {
 }
 
 func (p *Parser) expect(typ token.TokenType) error {
+	if p.cur.Is(token.ILLEGAL) {
+		return p.illegal()
+	}
 	if !p.cur.Is(typ) {
 		return fmt.Errorf("invalid token: %s, expecting %s", p.cur, typ)
 	}
@@ -54,11 +120,12 @@ func (p *Parser) Parse() (*ast.Program, error) {
 	defer p.trace("Parse")()
 	prog := &ast.Program{Tok: p.cur}
 	for !p.cur.Is(token.EOF) {
-		fn, err := p.funcDec()
+		stmts, err := p.declaration(true)
 		if err != nil {
 			return nil, err
 		}
-		prog.Statements = append(prog.Statements, fn)
+		prog.Statements = append(prog.Statements, p.enumDecs()...)
+		prog.Statements = append(prog.Statements, stmts...)
 	}
 	if err := p.expect(token.EOF); err != nil {
 		return nil, err
@@ -66,14 +133,18 @@ func (p *Parser) Parse() (*ast.Program, error) {
 	return prog, nil
 }
 
-func (p *Parser) withVarDec() (ast.Stmt, error) {
+// withVarDec parses a statement or a declaration, which may declare
+// several variables.
+func (p *Parser) withVarDec() ([]ast.Stmt, error) {
 	defer p.trace("StmtWithVarDec")()
-	switch {
-	case p.cur.Is(token.INT_TYPE):
-		return p.varDec()
-	default:
-		return p.stmt()
+	if p.isDeclaration(p.cur) {
+		return p.declaration(false)
 	}
+	stmt, err := p.stmt()
+	if err != nil {
+		return nil, err
+	}
+	return []ast.Stmt{stmt}, nil
 }
 
 func (p *Parser) block() (*ast.Block, error) {
@@ -82,12 +153,15 @@ func (p *Parser) block() (*ast.Block, error) {
 		return nil, err
 	}
 	block := &ast.Block{Tok: p.cur}
+	p.enterScope()
+	defer p.leaveScope()
 	for !p.cur.OneOf(token.RBRACE, token.EOF) {
-		stmt, err := p.withVarDec()
+		stmts, err := p.withVarDec()
 		if err != nil {
 			return nil, err
 		}
-		block.Statements = append(block.Statements, stmt)
+		block.Statements = append(block.Statements, p.enumDecs()...)
+		block.Statements = append(block.Statements, stmts...)
 	}
 	if err := p.expect(token.RBRACE); err != nil {
 		return nil, err
@@ -95,76 +169,519 @@ func (p *Parser) block() (*ast.Block, error) {
 	return block, nil
 }
 
-func (p *Parser) call() (*ast.Call, error) {
-	call := &ast.Call{Tok: p.cur, Name: p.cur.Text}
-	if err := p.expect(token.IDENT); err != nil {
+// typedef parses a typedef declaration and declares the
+// typedef names in the current scope.
+func (p *Parser) typedef() ([]ast.Stmt, error) {
+	defer p.trace("Typedef")()
+	tok := p.cur
+	if err := p.expect(token.TYPEDEF); err != nil {
 		return nil, err
 	}
-	if err := p.expect(token.LPAREN); err != nil {
+	base, err := p.typeSpec()
+	if err != nil {
 		return nil, err
 	}
-	for !p.cur.Is(token.RPAREN) {
-		arg, err := p.expr(false)
+	var decs []ast.Stmt
+	for {
+		d, err := p.declarator(false)
 		if err != nil {
 			return nil, err
 		}
-		call.Arguments = append(call.Arguments, arg)
+		dec := &ast.TypeDec{Tok: tok, Name: d.name, Type: d.apply(base)}
+		if err := p.scope.declare(dec.Name, dec.Type); err != nil {
+			return nil, fmt.Errorf("%v: %s", err, d.tok)
+		}
+		decs = append(decs, dec)
 		if !p.cur.Is(token.COMMA) {
 			break
 		}
-		if err := p.expect(token.COMMA); err != nil {
-			return nil, err
-		}
+		p.next()
 	}
-	if err := p.expect(token.RPAREN); err != nil {
+	if err := p.expect(token.SEMICOLON); err != nil {
 		return nil, err
 	}
-	return call, nil
+	return decs, nil
 }
 
-func (p *Parser) funcDec() (*ast.FuncDec, error) {
+// funcDec parses the body of a function definition whose
+// declarator has already been parsed.
+func (p *Parser) funcDec(fd *ast.FuncDec) (*ast.FuncDec, error) {
 	defer p.trace("FuncDec")()
-	fd := &ast.FuncDec{Tok: p.cur}
-	if err := p.expect(token.INT_TYPE); err != nil {
+	p.enterScope()
+	defer p.leaveScope()
+	for _, name := range fd.Params {
+		if name != "" {
+			p.scope.names[name] = nil
+		}
+	}
+	block, err := p.block()
+	if err != nil {
 		return nil, err
 	}
-	fd.Name = p.cur.Text
-	if err := p.expect(token.IDENT); err != nil {
+	fd.Body = block
+	return fd, nil
+}
+
+func (p *Parser) isTypeName(tok token.Token) bool {
+	if tok.Is(token.IDENT) {
+		return p.scope.lookupTypedef(tok.Text) != nil
+	}
+	return tok.OneOf(basicSpecifiers...) || tok.OneOf(token.VOID_TYPE, token.STRUCT, token.UNION, token.ENUM, token.CONST, token.VOLATILE)
+}
+
+// isDeclaration reports whether tok starts a declaration.
+func (p *Parser) isDeclaration(tok token.Token) bool {
+	return tok.OneOf(token.TYPEDEF, token.STATIC, token.EXTERN) || p.isTypeName(tok)
+}
+
+// typeSpec parses the type specifiers at the start of a declaration
+// along with the qualifiers which may precede or follow them.
+func (p *Parser) typeSpec() (types.Type, error) {
+	defer p.trace("TypeSpec")()
+	const1, volatile1 := p.qualifiers()
+	t, err := p.specifier()
+	if err != nil {
 		return nil, err
 	}
-	if err := p.expect(token.LPAREN); err != nil {
+	const2, volatile2 := p.qualifiers()
+	return types.Qualify(t, const1 || const2, volatile1 || volatile2), nil
+}
+
+// qualifiers parses a possibly empty list of type qualifiers.
+func (p *Parser) qualifiers() (isConst, isVolatile bool) {
+	for p.cur.OneOf(token.CONST, token.VOLATILE) {
+		if p.cur.Is(token.CONST) {
+			isConst = true
+		} else {
+			isVolatile = true
+		}
+		p.next()
+	}
+	return isConst, isVolatile
+}
+
+// specifier parses a type specifier.
+func (p *Parser) specifier() (types.Type, error) {
+	if p.cur.OneOf(token.STRUCT, token.UNION) {
+		return p.structSpec()
+	}
+	if p.cur.Is(token.ENUM) {
+		return p.enumSpec()
+	}
+	if p.cur.Is(token.IDENT) {
+		if t := p.scope.lookupTypedef(p.cur.Text); t != nil {
+			p.next()
+			return t, nil
+		}
+	}
+	if p.cur.Is(token.VOID_TYPE) {
+		p.next()
+		return types.Void, nil
+	}
+	if p.cur.OneOf(basicSpecifiers...) {
+		return p.basicSpec()
+	}
+	return nil, fmt.Errorf("expecting type: %s", p.cur)
+}
+
+var basicSpecifiers = []token.TokenType{
+	token.CHAR_TYPE,
+	token.SHORT_TYPE,
+	token.INT_TYPE,
+	token.LONG_TYPE,
+	token.SIGNED,
+	token.UNSIGNED,
+	token.BOOL_TYPE,
+}
+
+// basicSpec parses a list of integer type specifiers, which may
+// appear in any order, and returns the type they name.
+func (p *Parser) basicSpec() (types.Type, error) {
+	defer p.trace("BasicSpec")()
+	tok := p.cur
+	count := map[token.TokenType]int{}
+	for p.cur.OneOf(basicSpecifiers...) {
+		count[p.cur.Type]++
+		p.next()
+	}
+	invalid := fmt.Errorf("invalid combination of type specifiers: %s", tok)
+	for typ, n := range count {
+		if n > 1 && typ != token.LONG_TYPE {
+			return nil, invalid
+		}
+	}
+	signed, unsigned := count[token.SIGNED] > 0, count[token.UNSIGNED] > 0
+	if signed && unsigned {
+		return nil, invalid
+	}
+	pick := func(s, u types.Basic) types.Basic {
+		if unsigned {
+			return u
+		}
+		return s
+	}
+	switch {
+	case count[token.BOOL_TYPE] > 0:
+		if len(count) > 1 {
+			return nil, invalid
+		}
+		return types.Bool, nil
+	case count[token.CHAR_TYPE] > 0:
+		if count[token.SHORT_TYPE]+count[token.INT_TYPE]+count[token.LONG_TYPE] > 0 {
+			return nil, invalid
+		}
+		if signed {
+			return types.SChar, nil
+		}
+		return pick(types.Char, types.UChar), nil
+	case count[token.SHORT_TYPE] > 0:
+		if count[token.LONG_TYPE] > 0 {
+			return nil, invalid
+		}
+		return pick(types.Short, types.UShort), nil
+	case count[token.LONG_TYPE] == 1:
+		return pick(types.Long, types.ULong), nil
+	case count[token.LONG_TYPE] == 2:
+		return pick(types.LongLong, types.ULongLong), nil
+	case count[token.LONG_TYPE] > 2:
+		return nil, invalid
+	default:
+		return pick(types.Int, types.UInt), nil
+	}
+}
+
+// structSpec parses a struct or union specifier. A definition or a
+// declaration without a declarator always declares a new type in the
+// current scope, otherwise the tag refers to a previous declaration.
+func (p *Parser) structSpec() (*types.Struct, error) {
+	defer p.trace("StructSpec")()
+	tok := p.cur
+	union := p.cur.Is(token.UNION)
+	p.next()
+	var tag string
+	if p.cur.Is(token.IDENT) {
+		tag = p.cur.Text
+		p.next()
+	} else if !p.cur.Is(token.LBRACE) {
+		return nil, fmt.Errorf("expecting struct tag or definition: %s", p.cur)
+	}
+	var s *types.Struct
+	if tag != "" {
+		var t types.Type
+		if p.cur.OneOf(token.LBRACE, token.SEMICOLON) {
+			t = p.scope.tags[tag]
+		} else {
+			t = p.scope.lookupTag(tag)
+		}
+		if t == nil {
+			t = &types.Struct{Tag: tag, Union: union}
+			p.scope.tags[tag] = t
+		}
+		var ok bool
+		if s, ok = t.(*types.Struct); !ok || s.Union != union {
+			return nil, fmt.Errorf("%s defined as wrong kind of tag: %s", tag, tok)
+		}
+	} else {
+		s = &types.Struct{Union: union}
+	}
+	if !p.cur.Is(token.LBRACE) {
+		return s, nil
+	}
+	if s.Defined {
+		return nil, fmt.Errorf("redefinition of %s", s)
+	}
+	fields, err := p.fields()
+	if err != nil {
 		return nil, err
 	}
-	for !p.cur.Is(token.RPAREN) {
-		if err := p.expect(token.INT_TYPE); err != nil {
-			return nil, err
+	s.Fields = fields
+	s.Defined = true
+	return s, nil
+}
+
+// enumSpec parses an enum specifier. Enumerations have type int and
+// the enumerators are collected to be declared before the statement
+// containing the specifier.
+func (p *Parser) enumSpec() (types.Type, error) {
+	defer p.trace("EnumSpec")()
+	dec := &ast.EnumDec{Tok: p.cur}
+	if err := p.expect(token.ENUM); err != nil {
+		return nil, err
+	}
+	if p.cur.Is(token.IDENT) {
+		dec.Tag = p.cur.Text
+		p.next()
+	} else if !p.cur.Is(token.LBRACE) {
+		return nil, fmt.Errorf("expecting enum tag or definition: %s", p.cur)
+	}
+	if !p.cur.Is(token.LBRACE) {
+		t := p.scope.lookupTag(dec.Tag)
+		if t == nil {
+			return nil, fmt.Errorf("undefined enum %s: %s", dec.Tag, dec.Tok)
+		}
+		if types.IsStruct(t) {
+			return nil, fmt.Errorf("%s defined as wrong kind of tag: %s", dec.Tag, dec.Tok)
+		}
+		return types.Int, nil
+	}
+	if dec.Tag != "" {
+		if _, ok := p.scope.tags[dec.Tag]; ok {
+			return nil, fmt.Errorf("redefinition of enum %s: %s", dec.Tag, dec.Tok)
 		}
-		fd.Params = append(fd.Params, p.cur.Text)
+		p.scope.tags[dec.Tag] = types.Int
+	}
+	p.next()
+	for !p.cur.Is(token.RBRACE) {
+		e := &ast.Enumerator{Tok: p.cur, Name: p.cur.Text}
 		if err := p.expect(token.IDENT); err != nil {
 			return nil, err
 		}
+		if err := p.scope.declare(e.Name, nil); err != nil {
+			return nil, fmt.Errorf("%v: %s", err, e.Tok)
+		}
+		if p.cur.Is(token.ASSIGN) {
+			p.next()
+			value, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			e.Value = value
+		}
+		dec.Enumerators = append(dec.Enumerators, e)
 		if !p.cur.Is(token.COMMA) {
 			break
 		}
-		if err := p.expect(token.COMMA); err != nil {
+		p.next()
+	}
+	if err := p.expect(token.RBRACE); err != nil {
+		return nil, err
+	}
+	if len(dec.Enumerators) == 0 {
+		return nil, fmt.Errorf("enum has no enumerators: %s", dec.Tok)
+	}
+	p.enums = append(p.enums, dec)
+	return types.Int, nil
+}
+
+// enumDecs returns the enum declarations parsed since the last call.
+func (p *Parser) enumDecs() []ast.Stmt {
+	decs := p.enums
+	p.enums = nil
+	return decs
+}
+
+// fields parses the member declarations of a struct definition.
+func (p *Parser) fields() ([]*types.Field, error) {
+	defer p.trace("Fields")()
+	if err := p.expect(token.LBRACE); err != nil {
+		return nil, err
+	}
+	var fields []*types.Field
+	for !p.cur.Is(token.RBRACE) {
+		base, err := p.typeSpec()
+		if err != nil {
+			return nil, err
+		}
+		for {
+			tok := p.cur
+			d, err := p.declarator(false)
+			if err != nil {
+				return nil, err
+			}
+			f := &types.Field{Name: d.name, Type: d.apply(base)}
+			if _, ok := f.Type.(*types.Func); ok {
+				return nil, fmt.Errorf("field declared as a function: %s", tok)
+			}
+			if !types.IsComplete(f.Type) {
+				return nil, fmt.Errorf("field has incomplete type %s: %s", f.Type, tok)
+			}
+			for _, prev := range fields {
+				if prev.Name == f.Name {
+					return nil, fmt.Errorf("duplicate member %s: %s", f.Name, tok)
+				}
+			}
+			fields = append(fields, f)
+			if !p.cur.Is(token.COMMA) {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(token.SEMICOLON); err != nil {
 			return nil, err
 		}
 	}
-	if err := p.expect(token.RPAREN); err != nil {
+	if err := p.expect(token.RBRACE); err != nil {
 		return nil, err
 	}
-	if p.cur.Is(token.LBRACE) {
-		block, err := p.block()
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("struct has no members")
+	}
+	return fields, nil
+}
+
+// declarator is a parsed declarator. The declared type is
+// obtained by applying it to the base type from the specifiers.
+type declarator struct {
+	tok    token.Token
+	name   string
+	params []string
+	apply  func(types.Type) types.Type
+}
+
+// declarator parses a declarator. If abstract is true, the name
+// may be omitted as it is in parameter and type names.
+func (p *Parser) declarator(abstract bool) (*declarator, error) {
+	defer p.trace("Declarator")()
+	d := &declarator{tok: p.cur}
+	// the qualifiers of each pointer level follow its asterisk.
+	var pointers [][2]bool
+	for p.cur.Is(token.ASTERISK) {
+		p.next()
+		isConst, isVolatile := p.qualifiers()
+		pointers = append(pointers, [2]bool{isConst, isVolatile})
+	}
+	var inner *declarator
+	switch {
+	case p.cur.Is(token.IDENT):
+		d.tok, d.name = p.cur, p.cur.Text
+		p.next()
+	case p.cur.Is(token.LPAREN) && !p.isTypeName(p.peek) && !p.peek.Is(token.RPAREN):
+		p.next()
+		var err error
+		inner, err = p.declarator(abstract)
 		if err != nil {
 			return nil, err
 		}
-		fd.Body = block
-	} else {
-		if err := p.expect(token.SEMICOLON); err != nil {
+		if err := p.expect(token.RPAREN); err != nil {
 			return nil, err
 		}
+		d.tok, d.name, d.params = inner.tok, inner.name, inner.params
+	case !abstract:
+		return nil, fmt.Errorf("invalid declarator: %s", p.cur)
+	}
+	var suffixes []func(types.Type) types.Type
+	for p.cur.OneOf(token.LPAREN, token.LBRACKET) {
+		if p.cur.Is(token.LBRACKET) {
+			length, lenExpr, err := p.arrayLen()
+			if err != nil {
+				return nil, err
+			}
+			suffixes = append(suffixes, func(t types.Type) types.Type {
+				return &types.Array{Elem: t, Len: length, LenExpr: lenExpr}
+			})
+			continue
+		}
+		params, names, variadic, err := p.params()
+		if err != nil {
+			return nil, err
+		}
+		if inner == nil && len(suffixes) == 0 {
+			d.params = names
+		}
+		suffixes = append(suffixes, func(t types.Type) types.Type {
+			return &types.Func{Result: t, Params: params, Variadic: variadic}
+		})
 	}
-	return fd, nil
+	d.apply = func(t types.Type) types.Type {
+		for _, q := range pointers {
+			t = types.Qualify(&types.Pointer{Elem: t}, q[0], q[1])
+		}
+		for i := len(suffixes) - 1; i >= 0; i-- {
+			t = suffixes[i](t)
+		}
+		if inner != nil {
+			t = inner.apply(t)
+		}
+		return t
+	}
+	return d, nil
+}
+
+// arrayLen parses the length of an array declarator. Integer literal
+// lengths are returned directly, any other expression is left for the
+// compiler to evaluate. The length is -1 if it's omitted.
+func (p *Parser) arrayLen() (int, ast.Expr, error) {
+	defer p.trace("ArrayLen")()
+	if err := p.expect(token.LBRACKET); err != nil {
+		return 0, nil, err
+	}
+	if p.cur.Is(token.RBRACKET) {
+		p.next()
+		return -1, nil, nil
+	}
+	expr, err := p.ternary()
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := p.expect(token.RBRACKET); err != nil {
+		return 0, nil, err
+	}
+	if lit, ok := expr.(*ast.IntLit); ok {
+		return int(lit.Value), nil, nil
+	}
+	return -1, expr, nil
+}
+
+// params parses a function declarator's parameter list and returns
+// the parameter types and names. Unnamed parameters have empty names.
+// A trailing ellipsis makes the function variadic.
+func (p *Parser) params() ([]types.Type, []string, bool, error) {
+	defer p.trace("Params")()
+	if err := p.expect(token.LPAREN); err != nil {
+		return nil, nil, false, err
+	}
+	var (
+		params []types.Type
+		names  []string
+	)
+	// (void) declares a function without parameters
+	if p.cur.Is(token.VOID_TYPE) && p.peek.Is(token.RPAREN) {
+		p.next()
+	}
+	variadic := false
+	for !p.cur.Is(token.RPAREN) {
+		if p.cur.Is(token.ELLIPSIS) {
+			if len(params) == 0 {
+				return nil, nil, false, fmt.Errorf("a named parameter is required before ...: %s", p.cur)
+			}
+			p.next()
+			variadic = true
+			break
+		}
+		base, err := p.typeSpec()
+		if err != nil {
+			return nil, nil, false, err
+		}
+		d, err := p.declarator(true)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		typ := d.apply(base)
+		if typ == types.Void {
+			return nil, nil, false, fmt.Errorf("parameter %d has void type", len(params)+1)
+		}
+		// array and function parameters are adjusted to pointers.
+		if _, ok := typ.(*types.Func); ok || types.IsArray(typ) {
+			typ = types.Decay(typ)
+		}
+		params = append(params, typ)
+		names = append(names, d.name)
+		if !p.cur.Is(token.COMMA) {
+			break
+		}
+		if err := p.expect(token.COMMA); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if err := p.expect(token.RPAREN); err != nil {
+		return nil, nil, false, err
+	}
+	return params, names, variadic, nil
+}
+
+// illegal reports the lexer error carried by an ILLEGAL token.
+func (p *Parser) illegal() error {
+	return fmt.Errorf("%s: %s", p.cur.Pos, p.cur.Text)
 }
 
 func (p *Parser) trace(s string) func() {
@@ -188,6 +705,16 @@ func (p *Parser) stmt() (ast.Stmt, error) {
 		return p.doLoop()
 	case p.cur.Is(token.FOR):
 		return p.forLoop()
+	case p.cur.Is(token.SWITCH):
+		return p._switch()
+	case p.cur.Is(token.CASE):
+		return p._case()
+	case p.cur.Is(token.DEFAULT):
+		return p._default()
+	case p.cur.Is(token.GOTO):
+		return p._goto()
+	case p.cur.Is(token.IDENT) && p.peek.Is(token.COLON):
+		return p.label()
 	case p.cur.Is(token.CONTINUE):
 		return p._continue()
 	case p.cur.Is(token.BREAK):
@@ -197,28 +724,162 @@ func (p *Parser) stmt() (ast.Stmt, error) {
 	}
 }
 
-func (p *Parser) varDec() (*ast.VarDec, error) {
-	defer p.trace("VarDec")()
-	decl := &ast.VarDec{Tok: p.cur}
-	if err := p.expect(token.INT_TYPE); err != nil {
+// declaration parses a declaration at file scope when global is true
+// or at block scope otherwise. A statement is returned for each of the
+// declarators. Functions may only be declared at file scope and
+// defined by a declaration with a single declarator.
+func (p *Parser) declaration(global bool) ([]ast.Stmt, error) {
+	defer p.trace("Declaration")()
+	if p.cur.Is(token.TYPEDEF) {
+		return p.typedef()
+	}
+	tok := p.cur
+	storage, err := p.storageClass()
+	if err != nil {
 		return nil, err
 	}
-	decl.Name = p.cur.Text
-	if err := p.expect(token.IDENT); err != nil {
+	base, err := p.typeSpec()
+	if err != nil {
 		return nil, err
 	}
-	if p.cur.Is(token.ASSIGN) {
+	if p.cur.Is(token.SEMICOLON) {
 		p.next()
-		value, err := p.expr(false)
+		return []ast.Stmt{&ast.TypeDec{Tok: tok, Type: base}}, nil
+	}
+	var decs []ast.Stmt
+	for {
+		d, err := p.declarator(false)
 		if err != nil {
 			return nil, err
 		}
-		decl.Value = value
+		if err := p.scope.declare(d.name, nil); err != nil {
+			return nil, fmt.Errorf("%v: %s", err, d.tok)
+		}
+		typ := d.apply(base)
+		if fn, ok := typ.(*types.Func); ok {
+			if !global {
+				return nil, fmt.Errorf("function declarations must be at file scope: %s", d.name)
+			}
+			fd := &ast.FuncDec{
+				Tok:     tok,
+				Name:    d.name,
+				Type:    fn,
+				Params:  d.params,
+				Storage: storage,
+			}
+			if len(decs) == 0 && p.cur.Is(token.LBRACE) {
+				fd, err := p.funcDec(fd)
+				if err != nil {
+					return nil, err
+				}
+				return []ast.Stmt{fd}, nil
+			}
+			decs = append(decs, fd)
+		} else {
+			dec := &ast.VarDec{Tok: tok, Name: d.name, Type: typ, Storage: storage}
+			if p.cur.Is(token.ASSIGN) {
+				p.next()
+				if dec.Value, err = p.initializer(); err != nil {
+					return nil, err
+				}
+			}
+			decs = append(decs, dec)
+		}
+		if !p.cur.Is(token.COMMA) {
+			break
+		}
+		p.next()
 	}
 	if err := p.expect(token.SEMICOLON); err != nil {
 		return nil, err
 	}
-	return decl, nil
+	return decs, nil
+}
+
+// designation parses an initializer list entry, which may start with
+// designators naming the member or element it initializes.
+func (p *Parser) designation() (ast.Expr, error) {
+	defer p.trace("Designation")()
+	if !p.cur.OneOf(token.DOT, token.LBRACKET) {
+		return p.initializer()
+	}
+	d := &ast.Designation{Tok: p.cur}
+	for p.cur.OneOf(token.DOT, token.LBRACKET) {
+		if p.cur.Is(token.DOT) {
+			p.next()
+			d.Designators = append(d.Designators, ast.Designator{Field: p.cur.Text})
+			if err := p.expect(token.IDENT); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		p.next()
+		index, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(token.RBRACKET); err != nil {
+			return nil, err
+		}
+		d.Designators = append(d.Designators, ast.Designator{Index: index})
+	}
+	if err := p.expect(token.ASSIGN); err != nil {
+		return nil, err
+	}
+	var err error
+	if d.Value, err = p.initializer(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// storageClass parses the optional static or extern specifier at the
+// start of a declaration.
+func (p *Parser) storageClass() (ast.Storage, error) {
+	storage := ast.Auto
+	for p.cur.OneOf(token.STATIC, token.EXTERN, token.TYPEDEF) {
+		if storage != ast.Auto || p.cur.Is(token.TYPEDEF) {
+			return 0, fmt.Errorf("multiple storage classes in declaration specifiers: %s", p.cur)
+		}
+		if p.cur.Is(token.STATIC) {
+			storage = ast.Static
+		} else {
+			storage = ast.Extern
+		}
+		p.next()
+	}
+	return storage, nil
+}
+
+// initializer parses a variable initializer which is either an
+// expression or a brace enclosed list of initializers.
+func (p *Parser) initializer() (ast.Expr, error) {
+	defer p.trace("Initializer")()
+	if !p.cur.Is(token.LBRACE) {
+		return p.assign()
+	}
+	list := &ast.InitList{Tok: p.cur}
+	p.next()
+	for !p.cur.Is(token.RBRACE) {
+		value, err := p.designation()
+		if err != nil {
+			return nil, err
+		}
+		list.Values = append(list.Values, value)
+		if !p.cur.Is(token.COMMA) {
+			break
+		}
+		if err := p.expect(token.COMMA); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(token.RBRACE); err != nil {
+		return nil, err
+	}
+	if len(list.Values) == 0 {
+		return nil, fmt.Errorf("empty initializer list: %s", list.Tok)
+	}
+	return list, nil
 }
 
 func (p *Parser) exprStmt() (*ast.ExprStmt, error) {
@@ -265,6 +926,10 @@ func (p *Parser) ret() (*ast.Ret, error) {
 	if err := p.expect(token.RETURN); err != nil {
 		return nil, err
 	}
+	if p.cur.Is(token.SEMICOLON) {
+		p.next()
+		return ret, nil
+	}
 	expr, err := p.expr(false)
 	if err != nil {
 		return nil, err
@@ -300,6 +965,101 @@ func (p *Parser) whileLoop() (*ast.While, error) {
 	return w, nil
 }
 
+func (p *Parser) _switch() (*ast.Switch, error) {
+	defer p.trace("Switch")()
+	s := &ast.Switch{Tok: p.cur}
+	if err := p.expect(token.SWITCH); err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.LPAREN); err != nil {
+		return nil, err
+	}
+	var err error
+	s.Value, err = p.expr(false)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.RPAREN); err != nil {
+		return nil, err
+	}
+	s.Body, err = p.stmt()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *Parser) _case() (*ast.Case, error) {
+	defer p.trace("Case")()
+	c := &ast.Case{Tok: p.cur}
+	if err := p.expect(token.CASE); err != nil {
+		return nil, err
+	}
+	var err error
+	c.Value, err = p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.COLON); err != nil {
+		return nil, err
+	}
+	c.Body, err = p.stmt()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p *Parser) _default() (*ast.Default, error) {
+	defer p.trace("Default")()
+	d := &ast.Default{Tok: p.cur}
+	if err := p.expect(token.DEFAULT); err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.COLON); err != nil {
+		return nil, err
+	}
+	var err error
+	d.Body, err = p.stmt()
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (p *Parser) _goto() (*ast.Goto, error) {
+	defer p.trace("Goto")()
+	g := &ast.Goto{Tok: p.cur}
+	if err := p.expect(token.GOTO); err != nil {
+		return nil, err
+	}
+	g.Label = p.cur.Text
+	if err := p.expect(token.IDENT); err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.SEMICOLON); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (p *Parser) label() (*ast.Label, error) {
+	defer p.trace("Label")()
+	l := &ast.Label{Tok: p.cur, Name: p.cur.Text}
+	if err := p.expect(token.IDENT); err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.COLON); err != nil {
+		return nil, err
+	}
+	var err error
+	l.Body, err = p.stmt()
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
 func (p *Parser) doLoop() (*ast.Do, error) {
 	defer p.trace("Do")()
 	d := &ast.Do{Tok: p.cur}
@@ -339,6 +1099,9 @@ func (p *Parser) forLoop() (*ast.For, error) {
 	if err := p.expect(token.LPAREN); err != nil {
 		return nil, err
 	}
+	// names declared in the setup are only visible in the loop.
+	p.enterScope()
+	defer p.leaveScope()
 	var err error
 	f.Setup, err = p.withVarDec()
 	if err != nil {
@@ -408,7 +1171,25 @@ func (p *Parser) expr(nullable bool) (ast.Expr, error) {
 		}
 		return p.null(), nil
 	}
-	return p.assign()
+	return p.comma()
+}
+
+// comma parses expressions separated by the comma operator.
+func (p *Parser) comma() (ast.Expr, error) {
+	defer p.trace("Comma")()
+	expr, err := p.assign()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur.Is(token.COMMA) {
+		comma := &ast.Comma{Tok: p.cur, Left: expr}
+		p.next()
+		if comma.Right, err = p.assign(); err != nil {
+			return nil, err
+		}
+		expr = comma
+	}
+	return expr, nil
 }
 
 func (p *Parser) null() ast.Expr {
@@ -424,25 +1205,51 @@ func (p *Parser) variable() (*ast.Var, error) {
 	return v, nil
 }
 
+var compoundAssignOps = []token.TokenType{
+	token.PLUS_EQ,
+	token.MINUS_EQ,
+	token.ASTERISK_EQ,
+	token.SLASH_EQ,
+	token.PERCENT_EQ,
+	token.SHL_EQ,
+	token.SHR_EQ,
+	token.AMPERSAND_EQ,
+	token.PIPE_EQ,
+	token.CARET_EQ,
+}
+
 func (p *Parser) assign() (ast.Expr, error) {
 	defer p.trace("Assignment")()
 	expr, err := p.ternary()
 	if err != nil {
 		return nil, err
 	}
-	if !p.cur.Is(token.ASSIGN) {
+	switch {
+	case p.cur.Is(token.ASSIGN):
+		assign := &ast.Assign{Tok: p.cur, Target: expr}
+		p.next()
+		if !isLvalue(expr) {
+			return nil, fmt.Errorf("cannot assign to: %s", expr)
+		}
+		assign.Value, err = p.assign()
+		if err != nil {
+			return nil, err
+		}
+		return assign, nil
+	case p.cur.OneOf(compoundAssignOps...):
+		assign := &ast.CompoundAssign{Tok: p.cur, Op: p.cur.Text, Target: expr}
+		p.next()
+		if !isLvalue(expr) {
+			return nil, fmt.Errorf("cannot assign to: %s", expr)
+		}
+		assign.Value, err = p.assign()
+		if err != nil {
+			return nil, err
+		}
+		return assign, nil
+	default:
 		return expr, nil
 	}
-
-	assign := &ast.Assign{Tok: p.cur}
-	p.next()
-	v, ok := expr.(*ast.Var)
-	if !ok {
-		return nil, fmt.Errorf("cannot assign to: %s", expr)
-	}
-	assign.Var = v
-	assign.Value, err = p.expr(false)
-	return assign, nil
 }
 
 func (p *Parser) ternary() (ast.Expr, error) {
@@ -493,7 +1300,19 @@ func (p *Parser) or() (ast.Expr, error) {
 }
 
 func (p *Parser) and() (ast.Expr, error) {
-	return p.binary(p.equality, token.AND)
+	return p.binary(p.bitOr, token.AND)
+}
+
+func (p *Parser) bitOr() (ast.Expr, error) {
+	return p.binary(p.bitXor, token.PIPE)
+}
+
+func (p *Parser) bitXor() (ast.Expr, error) {
+	return p.binary(p.bitAnd, token.CARET)
+}
+
+func (p *Parser) bitAnd() (ast.Expr, error) {
+	return p.binary(p.equality, token.AMPERSAND)
 }
 
 func (p *Parser) equality() (ast.Expr, error) {
@@ -501,7 +1320,11 @@ func (p *Parser) equality() (ast.Expr, error) {
 }
 
 func (p *Parser) relational() (ast.Expr, error) {
-	return p.binary(p.additive, token.GT, token.LT, token.GT_EQ, token.LT_EQ)
+	return p.binary(p.shift, token.GT, token.LT, token.GT_EQ, token.LT_EQ)
+}
+
+func (p *Parser) shift() (ast.Expr, error) {
+	return p.binary(p.additive, token.SHL, token.SHR)
 }
 
 func (p *Parser) additive() (ast.Expr, error) {
@@ -515,24 +1338,260 @@ func (p *Parser) term() (ast.Expr, error) {
 func (p *Parser) factor() (ast.Expr, error) {
 	defer p.trace("factor")()
 	switch {
-	case p.cur.Is(token.IDENT) && p.peek.Is(token.LPAREN):
-		return p.call()
+	case p.isUnaryOp(p.cur):
+		return p.unaryOp()
+	case p.cur.OneOf(token.INC, token.DEC):
+		return p.prefix()
+	case p.cur.Is(token.LPAREN) && p.isTypeName(p.peek):
+		return p.cast()
+	case p.cur.Is(token.SIZEOF):
+		return p.sizeOf()
+	default:
+		return p.postfix()
+	}
+}
+
+func (p *Parser) sizeOf() (*ast.SizeOf, error) {
+	defer p.trace("SizeOf")()
+	s := &ast.SizeOf{Tok: p.cur}
+	if err := p.expect(token.SIZEOF); err != nil {
+		return nil, err
+	}
+	var err error
+	if p.cur.Is(token.LPAREN) && p.isTypeName(p.peek) {
+		p.next()
+		if s.Type, err = p.typeName(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(token.RPAREN); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	if s.Value, err = p.factor(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// cast parses a cast or a compound literal, which also starts with a
+// parenthesized type name.
+func (p *Parser) cast() (ast.Expr, error) {
+	defer p.trace("Cast")()
+	cast := &ast.Cast{Tok: p.cur}
+	if err := p.expect(token.LPAREN); err != nil {
+		return nil, err
+	}
+	typ, err := p.typeName()
+	if err != nil {
+		return nil, err
+	}
+	cast.Type = typ
+	if err := p.expect(token.RPAREN); err != nil {
+		return nil, err
+	}
+	if p.cur.Is(token.LBRACE) {
+		init, err := p.initializer()
+		if err != nil {
+			return nil, err
+		}
+		lit := &ast.CompoundLit{Tok: cast.Tok, Type: typ, Init: init.(*ast.InitList)}
+		return p.postfixOps(lit)
+	}
+	if cast.Value, err = p.factor(); err != nil {
+		return nil, err
+	}
+	return cast, nil
+}
+
+// typeName parses a type specifier followed by an abstract declarator.
+func (p *Parser) typeName() (types.Type, error) {
+	defer p.trace("TypeName")()
+	base, err := p.typeSpec()
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.declarator(true)
+	if err != nil {
+		return nil, err
+	}
+	if d.name != "" {
+		return nil, fmt.Errorf("unexpected name in type name: %s", d.tok)
+	}
+	return d.apply(base), nil
+}
+
+func (p *Parser) primary() (ast.Expr, error) {
+	defer p.trace("Primary")()
+	switch {
 	case p.cur.Is(token.IDENT):
 		return p.variable()
 	case p.cur.Is(token.INT_LIT):
 		return p.intLit()
+	case p.cur.Is(token.CHAR_LIT):
+		return p.charLit()
+	case p.cur.Is(token.STRING):
+		return p.stringLit()
 	case p.cur.Is(token.LPAREN):
 		return p.grouped()
-	case p.isUnaryOp(p.cur):
-		return p.unaryOp()
+	case p.cur.OneOf(token.VA_START, token.VA_ARG, token.VA_END):
+		return p.vaBuiltin()
+	case p.cur.Is(token.ILLEGAL):
+		return nil, p.illegal()
 	default:
 		return nil, fmt.Errorf("invalid factor: %s", p.cur)
 	}
 }
 
+// vaBuiltin parses a use of va_start, va_arg or va_end. They look
+// like calls, but va_arg takes a type name as its second argument.
+func (p *Parser) vaBuiltin() (ast.Expr, error) {
+	defer p.trace("VaBuiltin")()
+	tok := p.cur
+	p.next()
+	if err := p.expect(token.LPAREN); err != nil {
+		return nil, err
+	}
+	list, err := p.assign()
+	if err != nil {
+		return nil, err
+	}
+	var expr ast.Expr
+	switch tok.Type {
+	case token.VA_START:
+		if err := p.expect(token.COMMA); err != nil {
+			return nil, err
+		}
+		last, err := p.assign()
+		if err != nil {
+			return nil, err
+		}
+		expr = &ast.VaStart{Tok: tok, List: list, Last: last}
+	case token.VA_ARG:
+		if err := p.expect(token.COMMA); err != nil {
+			return nil, err
+		}
+		typ, err := p.typeName()
+		if err != nil {
+			return nil, err
+		}
+		expr = &ast.VaArg{Tok: tok, List: list, Type: typ}
+	default:
+		expr = &ast.VaEnd{Tok: tok, List: list}
+	}
+	if err := p.expect(token.RPAREN); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func (p *Parser) prefix() (ast.Expr, error) {
+	defer p.trace("Prefix")()
+	inc := &ast.IncDec{Tok: p.cur, Op: p.cur.Text}
+	p.next()
+	expr, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	if !isLvalue(expr) {
+		return nil, fmt.Errorf("cannot %s: %s", inc.Op, expr)
+	}
+	inc.Target = expr
+	return inc, nil
+}
+
+// call parses the argument list of a call to fn.
+func (p *Parser) call(fn ast.Expr) (*ast.Call, error) {
+	call := &ast.Call{Tok: p.cur, Func: fn}
+	if err := p.expect(token.LPAREN); err != nil {
+		return nil, err
+	}
+	for !p.cur.Is(token.RPAREN) {
+		arg, err := p.assign()
+		if err != nil {
+			return nil, err
+		}
+		call.Arguments = append(call.Arguments, arg)
+		if !p.cur.Is(token.COMMA) {
+			break
+		}
+		if err := p.expect(token.COMMA); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(token.RPAREN); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+func (p *Parser) postfix() (ast.Expr, error) {
+	defer p.trace("Postfix")()
+	expr, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	return p.postfixOps(expr)
+}
+
+// postfixOps parses the postfix operators applied to expr.
+func (p *Parser) postfixOps(expr ast.Expr) (ast.Expr, error) {
+	var err error
+	for p.cur.OneOf(token.INC, token.DEC, token.LBRACKET, token.LPAREN, token.DOT, token.ARROW) {
+		if p.cur.Is(token.LPAREN) {
+			if expr, err = p.call(expr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if p.cur.OneOf(token.DOT, token.ARROW) {
+			member := &ast.Member{Tok: p.cur, Value: expr, Arrow: p.cur.Is(token.ARROW)}
+			p.next()
+			member.Name = p.cur.Text
+			if err := p.expect(token.IDENT); err != nil {
+				return nil, err
+			}
+			expr = member
+			continue
+		}
+		if p.cur.Is(token.LBRACKET) {
+			index := &ast.Index{Tok: p.cur, Array: expr}
+			p.next()
+			if index.Index, err = p.expr(false); err != nil {
+				return nil, err
+			}
+			if err := p.expect(token.RBRACKET); err != nil {
+				return nil, err
+			}
+			expr = index
+			continue
+		}
+		if !isLvalue(expr) {
+			return nil, fmt.Errorf("cannot %s: %s", p.cur.Text, expr)
+		}
+		expr = &ast.IncDec{Tok: p.cur, Op: p.cur.Text, Postfix: true, Target: expr}
+		p.next()
+	}
+	return expr, nil
+}
+
+// isLvalue reports whether expr designates an object.
+func isLvalue(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Var, *ast.Index, *ast.CompoundLit:
+		return true
+	case *ast.UnaryOp:
+		return expr.Op == "*"
+	case *ast.Member:
+		return expr.Arrow || isLvalue(expr.Value)
+	default:
+		return false
+	}
+}
+
 func (p *Parser) isUnaryOp(tok token.Token) bool {
 	switch tok.Type {
-	case token.BANG, token.MINUS, token.TILDA:
+	case token.BANG, token.MINUS, token.TILDA, token.AMPERSAND, token.ASTERISK:
 		return true
 	default:
 		return false
@@ -572,11 +1631,76 @@ func (p *Parser) grouped() (ast.Expr, error) {
 func (p *Parser) intLit() (*ast.IntLit, error) {
 	defer p.trace("IntLit")()
 	lit := &ast.IntLit{Tok: p.cur}
-	value, err := strconv.Atoi(p.cur.Text)
+	digits := p.cur.Text[:len(p.cur.Text)-len(p.cur.Suffix)]
+	if p.cur.Radix == 16 || p.cur.Radix == 2 {
+		digits = digits[2:]
+	}
+	value, err := strconv.ParseUint(digits, p.cur.Radix, 64)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("integer constant is too large: %s", p.cur.Text)
 	}
 	lit.Value = value
+	for _, typ := range intLitTypes(p.cur.Radix, p.cur.Suffix) {
+		if value <= typ.Max() {
+			lit.Type = typ
+			break
+		}
+	}
+	if lit.Type == nil {
+		return nil, fmt.Errorf("integer constant is too large for its type: %s", p.cur.Text)
+	}
 	p.next()
 	return lit, nil
 }
+
+func (p *Parser) charLit() (*ast.CharLit, error) {
+	defer p.trace("CharLit")()
+	lit := &ast.CharLit{Tok: p.cur}
+	if err := p.expect(token.CHAR_LIT); err != nil {
+		return nil, err
+	}
+	// char is signed on i386
+	lit.Value = int(int8(lit.Tok.Value[0]))
+	return lit, nil
+}
+
+func (p *Parser) stringLit() (*ast.StringLit, error) {
+	defer p.trace("StringLit")()
+	lit := &ast.StringLit{Tok: p.cur}
+	var value strings.Builder
+	for p.cur.Is(token.STRING) {
+		value.WriteString(p.cur.Value)
+		p.next()
+	}
+	lit.Value = value.String()
+	return lit, nil
+}
+
+// intLitTypes returns the candidate types for an integer constant.
+// The constant has the first type in the list which can represent its value.
+func intLitTypes(radix int, suffix string) []types.Basic {
+	decimal := radix == 10
+	switch strings.ToLower(suffix) {
+	case "":
+		if decimal {
+			return []types.Basic{types.Int, types.Long, types.LongLong}
+		}
+		return []types.Basic{types.Int, types.UInt, types.Long, types.ULong, types.LongLong, types.ULongLong}
+	case "u":
+		return []types.Basic{types.UInt, types.ULong, types.ULongLong}
+	case "l":
+		if decimal {
+			return []types.Basic{types.Long, types.LongLong}
+		}
+		return []types.Basic{types.Long, types.ULong, types.LongLong, types.ULongLong}
+	case "ul", "lu":
+		return []types.Basic{types.ULong, types.ULongLong}
+	case "ll":
+		if decimal {
+			return []types.Basic{types.LongLong}
+		}
+		return []types.Basic{types.LongLong, types.ULongLong}
+	default:
+		return []types.Basic{types.ULongLong}
+	}
+}
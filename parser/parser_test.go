@@ -11,6 +11,7 @@ import (
 
 	"github.com/icholy/cc/ast"
 	"github.com/icholy/cc/token"
+	"github.com/icholy/cc/types"
 
 	"gotest.tools/assert"
 )
@@ -25,6 +26,42 @@ func TestValidParsing(t *testing.T) {
 	AssertParsingStage(t, 7)
 	AssertParsingStage(t, 8)
 	AssertParsingStage(t, 9)
+	AssertParsingStage(t, 10)
+	AssertParsingStage(t, 11)
+	AssertParsingStage(t, 12)
+	AssertParsingStage(t, 13)
+	AssertParsingStage(t, 14)
+	AssertParsingStage(t, 15)
+	AssertParsingStage(t, 16)
+	AssertParsingStage(t, 17)
+	AssertParsingStage(t, 18)
+	AssertParsingStage(t, 19)
+	AssertParsingStage(t, 20)
+	AssertParsingStage(t, 21)
+	AssertParsingStage(t, 22)
+	AssertParsingStage(t, 23)
+	AssertParsingStage(t, 24)
+	AssertParsingStage(t, 25)
+	AssertParsingStage(t, 26)
+	AssertParsingStage(t, 27)
+	AssertParsingStage(t, 28)
+	AssertParsingStage(t, 29)
+	AssertParsingStage(t, 30)
+	AssertParsingStage(t, 31)
+	AssertParsingStage(t, 32)
+	AssertParsingStage(t, 33)
+}
+
+func withBody(stmts ...ast.Stmt) *ast.Program {
+	return &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{Statements: stmts},
+			},
+		},
+	}
 }
 
 func withRetval(retval ast.Expr) *ast.Program {
@@ -32,6 +69,7 @@ func withRetval(retval ast.Expr) *ast.Program {
 		Statements: []ast.Stmt{
 			&ast.FuncDec{
 				Name: "main",
+				Type: &types.Func{Result: types.Int},
 				Body: &ast.Block{
 					Statements: []ast.Stmt{
 						&ast.Ret{
@@ -44,18 +82,19 @@ func withRetval(retval ast.Expr) *ast.Program {
 	}
 }
 func TestAST(t *testing.T) {
-	AssertEqualAST(t, "../testdata/stage_1/valid/return_2.c", withRetval(&ast.IntLit{Value: 2}))
+	AssertEqualAST(t, "../testdata/stage_1/valid/return_2.c", withRetval(&ast.IntLit{Value: 2, Type: types.Int}))
 	AssertEqualAST(t, "../testdata/stage_2/valid/neg.c", withRetval(&ast.UnaryOp{
 		Op: "-",
 		Value: &ast.IntLit{
 			Value: 5,
+			Type:  types.Int,
 		},
 	}))
 	AssertEqualAST(t, "../testdata/stage_3/valid/add.c", withRetval(
 		&ast.BinaryOp{
 			Op:    "+",
-			Left:  &ast.IntLit{Value: 1},
-			Right: &ast.IntLit{Value: 2},
+			Left:  &ast.IntLit{Value: 1, Type: types.Int},
+			Right: &ast.IntLit{Value: 2, Type: types.Int},
 		},
 	))
 	AssertEqualAST(t, "../testdata/stage_3/valid/associativity.c", withRetval(
@@ -63,38 +102,867 @@ func TestAST(t *testing.T) {
 			Op: "-",
 			Left: &ast.BinaryOp{
 				Op:    "-",
-				Left:  &ast.IntLit{Value: 1},
-				Right: &ast.IntLit{Value: 2},
+				Left:  &ast.IntLit{Value: 1, Type: types.Int},
+				Right: &ast.IntLit{Value: 2, Type: types.Int},
 			},
-			Right: &ast.IntLit{Value: 3},
+			Right: &ast.IntLit{Value: 3, Type: types.Int},
 		},
 	))
 	AssertEqualAST(t, "../testdata/stage_3/valid/precedence.c", withRetval(
 		&ast.BinaryOp{
 			Op:   "+",
-			Left: &ast.IntLit{Value: 2},
+			Left: &ast.IntLit{Value: 2, Type: types.Int},
 			Right: &ast.BinaryOp{
 				Op:    "*",
-				Left:  &ast.IntLit{Value: 3},
-				Right: &ast.IntLit{Value: 4},
+				Left:  &ast.IntLit{Value: 3, Type: types.Int},
+				Right: &ast.IntLit{Value: 4, Type: types.Int},
+			},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_11/valid/hex_unsigned.c", withRetval(
+		&ast.BinaryOp{
+			Op:    "+",
+			Left:  &ast.IntLit{Value: 0xFFFFFFFF, Type: types.UInt},
+			Right: &ast.IntLit{Value: 5, Type: types.Int},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_11/valid/long.c", withRetval(
+		&ast.BinaryOp{
+			Op:    "-",
+			Left:  &ast.IntLit{Value: 42, Type: types.Long},
+			Right: &ast.IntLit{Value: 2, Type: types.Long},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_11/valid/unsigned_long.c", withRetval(
+		&ast.BinaryOp{
+			Op:    "+",
+			Left:  &ast.IntLit{Value: 7, Type: types.ULong},
+			Right: &ast.IntLit{Value: 1, Type: types.ULong},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_11/invalid/long_long__no_parse.c", withRetval(
+		&ast.IntLit{Value: 2147483648, Type: types.LongLong},
+	))
+	AssertEqualAST(t, "../testdata/stage_12/valid/signed_char.c", withRetval(
+		&ast.BinaryOp{
+			Op: "==",
+			Left: &ast.BinaryOp{
+				Op:    "+",
+				Left:  &ast.CharLit{Value: -1},
+				Right: &ast.IntLit{Value: 1, Type: types.Int},
+			},
+			Right: &ast.IntLit{Value: 0, Type: types.Int},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_13/valid/concat.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name:   "puts",
+				Type:   &types.Func{Result: types.Int, Params: []types.Type{&types.Pointer{Elem: types.Char}}},
+				Params: []string{"s"},
+			},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.ExprStmt{
+							Expr: &ast.Call{
+								Func: &ast.Var{Name: "puts"},
+								Arguments: []ast.Expr{
+									&ast.StringLit{Value: "Hello, World!"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_14/valid/precedence.c", withRetval(
+		&ast.BinaryOp{
+			Op:   "|",
+			Left: &ast.IntLit{Value: 1, Type: types.Int},
+			Right: &ast.BinaryOp{
+				Op: "^",
+				Left: &ast.BinaryOp{
+					Op:    "&",
+					Left:  &ast.IntLit{Value: 2, Type: types.Int},
+					Right: &ast.IntLit{Value: 3, Type: types.Int},
+				},
+				Right: &ast.IntLit{Value: 4, Type: types.Int},
+			},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_14/valid/shift_precedence.c", withRetval(
+		&ast.BinaryOp{
+			Op:   "<<",
+			Left: &ast.IntLit{Value: 1, Type: types.Int},
+			Right: &ast.BinaryOp{
+				Op:    "+",
+				Left:  &ast.IntLit{Value: 2, Type: types.Int},
+				Right: &ast.IntLit{Value: 1, Type: types.Int},
 			},
 		},
 	))
+	AssertEqualAST(t, "../testdata/stage_15/valid/plus_plus_plus.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "a",
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 1, Type: types.Int},
+						},
+						&ast.VarDec{
+							Name:  "b",
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 2, Type: types.Int},
+						},
+						&ast.VarDec{
+							Name: "c",
+							Type: types.Int,
+							Value: &ast.BinaryOp{
+								Op:    "+",
+								Left:  &ast.IncDec{Op: "++", Postfix: true, Target: &ast.Var{Name: "a"}},
+								Right: &ast.Var{Name: "b"},
+							},
+						},
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op: "+",
+								Left: &ast.BinaryOp{
+									Op:    "*",
+									Left:  &ast.Var{Name: "a"},
+									Right: &ast.IntLit{Value: 10, Type: types.Int},
+								},
+								Right: &ast.Var{Name: "c"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_17/valid/global.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.VarDec{
+				Name:  "x",
+				Type:  types.Int,
+				Value: &ast.IntLit{Value: 5, Type: types.Int},
+			},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.Ret{
+							Value: &ast.Var{Name: "x"},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_18/valid/address_of.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "a",
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 3, Type: types.Int},
+						},
+						&ast.VarDec{
+							Name:  "p",
+							Type:  &types.Pointer{Elem: types.Int},
+							Value: &ast.UnaryOp{Op: "&", Value: &ast.Var{Name: "a"}},
+						},
+						&ast.Ret{
+							Value: &ast.UnaryOp{Op: "*", Value: &ast.Var{Name: "p"}},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_19/valid/array_init.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name: "a",
+							Type: &types.Array{Elem: types.Int, Len: -1},
+							Value: &ast.InitList{
+								Values: []ast.Expr{
+									&ast.IntLit{Value: 4, Type: types.Int},
+									&ast.IntLit{Value: 5, Type: types.Int},
+									&ast.IntLit{Value: 6, Type: types.Int},
+								},
+							},
+						},
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op: "+",
+								Left: &ast.BinaryOp{
+									Op: "+",
+									Left: &ast.BinaryOp{
+										Op:    "*",
+										Left:  &ast.Index{Array: &ast.Var{Name: "a"}, Index: &ast.IntLit{Value: 0, Type: types.Int}},
+										Right: &ast.IntLit{Value: 100, Type: types.Int},
+									},
+									Right: &ast.BinaryOp{
+										Op:    "*",
+										Left:  &ast.Index{Array: &ast.Var{Name: "a"}, Index: &ast.IntLit{Value: 1, Type: types.Int}},
+										Right: &ast.IntLit{Value: 10, Type: types.Int},
+									},
+								},
+								Right: &ast.Index{Array: &ast.Var{Name: "a"}, Index: &ast.IntLit{Value: 2, Type: types.Int}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_21/valid/enum.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.EnumDec{
+				Tag: "color",
+				Enumerators: []*ast.Enumerator{
+					{Name: "RED"},
+					{Name: "GREEN", Value: &ast.IntLit{Value: 5, Type: types.Int}},
+					{Name: "BLUE"},
+				},
+			},
+			&ast.TypeDec{Type: types.Int},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op: "+",
+								Left: &ast.BinaryOp{
+									Op:   "+",
+									Left: &ast.Var{Name: "RED"},
+									Right: &ast.BinaryOp{
+										Op:    "*",
+										Left:  &ast.Var{Name: "GREEN"},
+										Right: &ast.IntLit{Value: 10, Type: types.Int},
+									},
+								},
+								Right: &ast.Var{Name: "BLUE"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_23/valid/char.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "c",
+							Type:  types.Char,
+							Value: &ast.IntLit{Value: 200, Type: types.Int},
+						},
+						&ast.VarDec{
+							Name:  "u",
+							Type:  types.UChar,
+							Value: &ast.IntLit{Value: 300, Type: types.Int},
+						},
+						&ast.VarDec{
+							Name: "s",
+							Type: types.SChar,
+							Value: &ast.UnaryOp{
+								Op:    "-",
+								Value: &ast.IntLit{Value: 1, Type: types.Int},
+							},
+						},
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op: "+",
+								Left: &ast.BinaryOp{
+									Op: "+",
+									Left: &ast.BinaryOp{
+										Op:    "<",
+										Left:  &ast.Var{Name: "c"},
+										Right: &ast.IntLit{Value: 0, Type: types.Int},
+									},
+									Right: &ast.Var{Name: "u"},
+								},
+								Right: &ast.BinaryOp{
+									Op:    "==",
+									Left:  &ast.Var{Name: "s"},
+									Right: &ast.UnaryOp{Op: "-", Value: &ast.IntLit{Value: 1, Type: types.Int}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_24/valid/void_prototype.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name: "set",
+				Type: &types.Func{
+					Result: types.Void,
+					Params: []types.Type{&types.Pointer{Elem: types.Int}, types.Int},
+				},
+				Params: []string{"p", "v"},
+			},
+			&ast.FuncDec{
+				Name: "get",
+				Type: &types.Func{Result: types.Int},
+			},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "x",
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 0, Type: types.Int},
+						},
+						&ast.ExprStmt{
+							Expr: &ast.Call{
+								Func: &ast.Var{Name: "set"},
+								Arguments: []ast.Expr{
+									&ast.UnaryOp{Op: "&", Value: &ast.Var{Name: "x"}},
+									&ast.IntLit{Value: 9, Type: types.Int},
+								},
+							},
+						},
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op:    "+",
+								Left:  &ast.Var{Name: "x"},
+								Right: &ast.Call{Func: &ast.Var{Name: "get"}},
+							},
+						},
+					},
+				},
+			},
+			&ast.FuncDec{
+				Name: "set",
+				Type: &types.Func{
+					Result: types.Void,
+					Params: []types.Type{&types.Pointer{Elem: types.Int}, types.Int},
+				},
+				Params: []string{"p", "v"},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.ExprStmt{
+							Expr: &ast.Assign{
+								Target: &ast.UnaryOp{Op: "*", Value: &ast.Var{Name: "p"}},
+								Value:  &ast.Var{Name: "v"},
+							},
+						},
+					},
+				},
+			},
+			&ast.FuncDec{
+				Name: "get",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.Ret{Value: &ast.IntLit{Value: 2, Type: types.Int}},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_25/valid/switch_no_default.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "x",
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 5, Type: types.Int},
+						},
+						&ast.Switch{
+							Value: &ast.Var{Name: "x"},
+							Body: &ast.Block{
+								Statements: []ast.Stmt{
+									&ast.Case{
+										Value: &ast.IntLit{Value: 1, Type: types.Int},
+										Body: &ast.ExprStmt{
+											Expr: &ast.Assign{
+												Target: &ast.Var{Name: "x"},
+												Value:  &ast.IntLit{Value: 100, Type: types.Int},
+											},
+										},
+									},
+									&ast.Break{},
+									&ast.Case{
+										Value: &ast.IntLit{Value: 2, Type: types.Int},
+										Body: &ast.ExprStmt{
+											Expr: &ast.Assign{
+												Target: &ast.Var{Name: "x"},
+												Value:  &ast.IntLit{Value: 200, Type: types.Int},
+											},
+										},
+									},
+									&ast.Break{},
+								},
+							},
+						},
+						&ast.Ret{Value: &ast.Var{Name: "x"}},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_26/valid/goto_forward.c", withBody(
+		&ast.VarDec{
+			Name:  "x",
+			Type:  types.Int,
+			Value: &ast.IntLit{Value: 1, Type: types.Int},
+		},
+		&ast.Goto{Label: "skip"},
+		&ast.ExprStmt{
+			Expr: &ast.Assign{
+				Target: &ast.Var{Name: "x"},
+				Value:  &ast.IntLit{Value: 100, Type: types.Int},
+			},
+		},
+		&ast.Label{
+			Name: "skip",
+			Body: &ast.Ret{
+				Value: &ast.BinaryOp{
+					Op:    "+",
+					Left:  &ast.Var{Name: "x"},
+					Right: &ast.IntLit{Value: 2, Type: types.Int},
+				},
+			},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_27/valid/multi_decl_init_order.c", withBody(
+		&ast.VarDec{
+			Name:  "a",
+			Type:  types.Int,
+			Value: &ast.IntLit{Value: 2, Type: types.Int},
+		},
+		&ast.VarDec{
+			Name: "b",
+			Type: types.Int,
+			Value: &ast.BinaryOp{
+				Op:    "*",
+				Left:  &ast.Var{Name: "a"},
+				Right: &ast.IntLit{Value: 3, Type: types.Int},
+			},
+		},
+		&ast.VarDec{
+			Name: "c",
+			Type: types.Int,
+			Value: &ast.BinaryOp{
+				Op:    "+",
+				Left:  &ast.Var{Name: "a"},
+				Right: &ast.Var{Name: "b"},
+			},
+		},
+		&ast.Ret{Value: &ast.Var{Name: "c"}},
+	))
+	AssertEqualAST(t, "../testdata/stage_27/valid/comma.c", withBody(
+		&ast.VarDec{Name: "a", Type: types.Int},
+		&ast.VarDec{Name: "b", Type: types.Int},
+		&ast.ExprStmt{
+			Expr: &ast.Comma{
+				Left: &ast.Assign{
+					Target: &ast.Var{Name: "a"},
+					Value:  &ast.IntLit{Value: 1, Type: types.Int},
+				},
+				Right: &ast.Assign{
+					Target: &ast.Var{Name: "b"},
+					Value:  &ast.IntLit{Value: 2, Type: types.Int},
+				},
+			},
+		},
+		&ast.VarDec{
+			Name: "c",
+			Type: types.Int,
+			Value: &ast.Comma{
+				Left: &ast.CompoundAssign{
+					Op:     "+=",
+					Target: &ast.Var{Name: "a"},
+					Value:  &ast.IntLit{Value: 3, Type: types.Int},
+				},
+				Right: &ast.BinaryOp{
+					Op:    "*",
+					Left:  &ast.Var{Name: "b"},
+					Right: &ast.Var{Name: "a"},
+				},
+			},
+		},
+		&ast.Ret{Value: &ast.Var{Name: "c"}},
+	))
+	AssertEqualAST(t, "../testdata/stage_28/valid/sizeof_unsigned.c", withRetval(
+		&ast.BinaryOp{
+			Op: "+",
+			Left: &ast.BinaryOp{
+				Op: ">",
+				Left: &ast.BinaryOp{
+					Op:    "-",
+					Left:  &ast.SizeOf{Type: types.Int},
+					Right: &ast.IntLit{Value: 5, Type: types.Int},
+				},
+				Right: &ast.IntLit{Value: 0, Type: types.Int},
+			},
+			Right: &ast.BinaryOp{
+				Op: "*",
+				Left: &ast.BinaryOp{
+					Op:    "<",
+					Left:  &ast.SizeOf{Type: types.Char},
+					Right: &ast.UnaryOp{Op: "-", Value: &ast.IntLit{Value: 1, Type: types.Int}},
+				},
+				Right: &ast.IntLit{Value: 2, Type: types.Int},
+			},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_29/valid/funcptr.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name:   "add",
+				Type:   &types.Func{Result: types.Int, Params: []types.Type{types.Int, types.Int}},
+				Params: []string{"a", "b"},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op:    "+",
+								Left:  &ast.Var{Name: "a"},
+								Right: &ast.Var{Name: "b"},
+							},
+						},
+					},
+				},
+			},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name: "op",
+							Type: &types.Pointer{
+								Elem: &types.Func{Result: types.Int, Params: []types.Type{types.Int, types.Int}},
+							},
+							Value: &ast.Var{Name: "add"},
+						},
+						&ast.Ret{
+							Value: &ast.Call{
+								Func: &ast.Var{Name: "op"},
+								Arguments: []ast.Expr{
+									&ast.IntLit{Value: 3, Type: types.Int},
+									&ast.IntLit{Value: 4, Type: types.Int},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_30/valid/static_main.c", withBody(
+		&ast.VarDec{
+			Name:    "n",
+			Type:    types.Int,
+			Value:   &ast.IntLit{Value: 4, Type: types.Int},
+			Storage: ast.Static,
+		},
+		&ast.ExprStmt{
+			Expr: &ast.CompoundAssign{
+				Op:     "*=",
+				Target: &ast.Var{Name: "n"},
+				Value:  &ast.IntLit{Value: 2, Type: types.Int},
+			},
+		},
+		&ast.Ret{Value: &ast.Var{Name: "n"}},
+	))
+	AssertEqualAST(t, "../testdata/stage_31/valid/const_pointer.c", withBody(
+		&ast.VarDec{
+			Name:  "a",
+			Type:  types.Int,
+			Value: &ast.IntLit{Value: 1, Type: types.Int},
+		},
+		&ast.VarDec{
+			Name:  "b",
+			Type:  types.Int,
+			Value: &ast.IntLit{Value: 2, Type: types.Int},
+		},
+		&ast.VarDec{
+			Name: "p",
+			Type: &types.Qualified{
+				Type:  &types.Pointer{Elem: types.Int},
+				Const: true,
+			},
+			Value: &ast.UnaryOp{Op: "&", Value: &ast.Var{Name: "a"}},
+		},
+		&ast.VarDec{
+			Name: "q",
+			Type: &types.Pointer{
+				Elem: &types.Qualified{Type: types.Int, Const: true},
+			},
+			Value: &ast.UnaryOp{Op: "&", Value: &ast.Var{Name: "a"}},
+		},
+		&ast.ExprStmt{
+			Expr: &ast.Assign{
+				Target: &ast.UnaryOp{Op: "*", Value: &ast.Var{Name: "p"}},
+				Value:  &ast.IntLit{Value: 7, Type: types.Int},
+			},
+		},
+		&ast.ExprStmt{
+			Expr: &ast.Assign{
+				Target: &ast.Var{Name: "q"},
+				Value:  &ast.UnaryOp{Op: "&", Value: &ast.Var{Name: "b"}},
+			},
+		},
+		&ast.Ret{
+			Value: &ast.BinaryOp{
+				Op:    "+",
+				Left:  &ast.UnaryOp{Op: "*", Value: &ast.Var{Name: "p"}},
+				Right: &ast.UnaryOp{Op: "*", Value: &ast.Var{Name: "q"}},
+			},
+		},
+	))
+	firstType := &types.Func{Result: types.Int, Params: []types.Type{types.Int}, Variadic: true}
+	AssertEqualAST(t, "../testdata/stage_32/valid/variadic_pointer.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.FuncDec{
+				Name:   "first",
+				Type:   firstType,
+				Params: []string{"n"},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{Name: "ap", Type: types.VaList},
+						&ast.ExprStmt{
+							Expr: &ast.VaStart{List: &ast.Var{Name: "ap"}, Last: &ast.Var{Name: "n"}},
+						},
+						&ast.VarDec{
+							Name:  "v",
+							Type:  types.Int,
+							Value: &ast.VaArg{List: &ast.Var{Name: "ap"}, Type: types.Int},
+						},
+						&ast.ExprStmt{
+							Expr: &ast.VaEnd{List: &ast.Var{Name: "ap"}},
+						},
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op:    "+",
+								Left:  &ast.Var{Name: "v"},
+								Right: &ast.Var{Name: "n"},
+							},
+						},
+					},
+				},
+			},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "fp",
+							Type:  &types.Pointer{Elem: firstType},
+							Value: &ast.Var{Name: "first"},
+						},
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op: "+",
+								Left: &ast.Call{
+									Func: &ast.Var{Name: "fp"},
+									Arguments: []ast.Expr{
+										&ast.IntLit{Value: 1, Type: types.Int},
+										&ast.IntLit{Value: 10, Type: types.Int},
+										&ast.IntLit{Value: 20, Type: types.Int},
+									},
+								},
+								Right: &ast.Call{
+									Func: &ast.Var{Name: "first"},
+									Arguments: []ast.Expr{
+										&ast.IntLit{Value: 2, Type: types.Int},
+										&ast.IntLit{Value: 30, Type: types.Int},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_33/valid/designated_nested.c", withBody(
+		&ast.VarDec{
+			Name: "m",
+			Type: &types.Array{Elem: &types.Array{Elem: types.Int, Len: 2}, Len: 2},
+			Value: &ast.InitList{
+				Values: []ast.Expr{
+					&ast.Designation{
+						Designators: []ast.Designator{
+							{Index: &ast.IntLit{Value: 1, Type: types.Int}},
+							{Index: &ast.IntLit{Value: 0, Type: types.Int}},
+						},
+						Value: &ast.IntLit{Value: 3, Type: types.Int},
+					},
+					&ast.Designation{
+						Designators: []ast.Designator{
+							{Index: &ast.IntLit{Value: 0, Type: types.Int}},
+						},
+						Value: &ast.InitList{
+							Values: []ast.Expr{
+								&ast.IntLit{Value: 1, Type: types.Int},
+								&ast.IntLit{Value: 2, Type: types.Int},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.Ret{
+			Value: &ast.BinaryOp{
+				Op: "+",
+				Left: &ast.Index{
+					Array: &ast.Index{Array: &ast.Var{Name: "m"}, Index: &ast.IntLit{Value: 0, Type: types.Int}},
+					Index: &ast.IntLit{Value: 1, Type: types.Int},
+				},
+				Right: &ast.Index{
+					Array: &ast.Index{Array: &ast.Var{Name: "m"}, Index: &ast.IntLit{Value: 1, Type: types.Int}},
+					Index: &ast.IntLit{Value: 0, Type: types.Int},
+				},
+			},
+		},
+	))
+	AssertEqualAST(t, "../testdata/stage_22/valid/typedef_shadow_for.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.TypeDec{Name: "T", Type: types.Int},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "y",
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 0, Type: types.Int},
+						},
+						&ast.For{
+							Setup: []ast.Stmt{
+								&ast.VarDec{
+									Name:  "T",
+									Type:  types.Int,
+									Value: &ast.IntLit{Value: 2, Type: types.Int},
+								},
+							},
+							Condition: &ast.BinaryOp{
+								Op:    "<",
+								Left:  &ast.Var{Name: "T"},
+								Right: &ast.IntLit{Value: 4, Type: types.Int},
+							},
+							Increment: &ast.IncDec{Op: "++", Postfix: true, Target: &ast.Var{Name: "T"}},
+							Body: &ast.ExprStmt{
+								Expr: &ast.Assign{
+									Target: &ast.Var{Name: "y"},
+									Value: &ast.BinaryOp{
+										Op:    "+",
+										Left:  &ast.Var{Name: "y"},
+										Right: &ast.Var{Name: "T"},
+									},
+								},
+							},
+						},
+						&ast.VarDec{
+							Name:  "x",
+							Type:  types.Int,
+							Value: &ast.Var{Name: "y"},
+						},
+						&ast.Ret{Value: &ast.Var{Name: "x"}},
+					},
+				},
+			},
+		},
+	})
+	AssertEqualAST(t, "../testdata/stage_22/valid/typedef_cast.c", &ast.Program{
+		Statements: []ast.Stmt{
+			&ast.TypeDec{Name: "number", Type: types.Int},
+			&ast.TypeDec{Name: "pointer", Type: &types.Pointer{Elem: types.Int}},
+			&ast.FuncDec{
+				Name: "main",
+				Type: &types.Func{Result: types.Int},
+				Body: &ast.Block{
+					Statements: []ast.Stmt{
+						&ast.VarDec{
+							Name:  "x",
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 7, Type: types.Int},
+						},
+						&ast.VarDec{
+							Name: "p",
+							Type: &types.Pointer{Elem: types.Int},
+							Value: &ast.Cast{
+								Type:  &types.Pointer{Elem: types.Int},
+								Value: &ast.UnaryOp{Op: "&", Value: &ast.Var{Name: "x"}},
+							},
+						},
+						&ast.Ret{
+							Value: &ast.BinaryOp{
+								Op: "+",
+								Left: &ast.Cast{
+									Type:  types.Int,
+									Value: &ast.UnaryOp{Op: "*", Value: &ast.Var{Name: "p"}},
+								},
+								Right: &ast.Cast{
+									Type: types.Int,
+									Value: &ast.BinaryOp{
+										Op:    "*",
+										Left:  &ast.Var{Name: "x"},
+										Right: &ast.IntLit{Value: 2, Type: types.Int},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
 	AssertEqualAST(t, "../testdata/stage_4/valid/eq_true.c", withRetval(
 		&ast.BinaryOp{
 			Op:    "==",
-			Left:  &ast.IntLit{Value: 1},
-			Right: &ast.IntLit{Value: 1},
+			Left:  &ast.IntLit{Value: 1, Type: types.Int},
+			Right: &ast.IntLit{Value: 1, Type: types.Int},
 		},
 	))
 	AssertEqualAST(t, "../testdata/stage_6/valid/return_ternary.c", withRetval(
 		&ast.Ternary{
-			Condition: &ast.IntLit{Value: 1},
-			Then:      &ast.IntLit{Value: 2},
+			Condition: &ast.IntLit{Value: 1, Type: types.Int},
+			Then:      &ast.IntLit{Value: 2, Type: types.Int},
 			Else: &ast.Ternary{
-				Condition: &ast.IntLit{Value: 3},
-				Then:      &ast.IntLit{Value: 4},
-				Else:      &ast.IntLit{Value: 5},
+				Condition: &ast.IntLit{Value: 3, Type: types.Int},
+				Then:      &ast.IntLit{Value: 4, Type: types.Int},
+				Else:      &ast.IntLit{Value: 5, Type: types.Int},
 			},
 		},
 	))
@@ -102,19 +970,21 @@ func TestAST(t *testing.T) {
 		Statements: []ast.Stmt{
 			&ast.FuncDec{
 				Name: "main",
+				Type: &types.Func{Result: types.Int},
 				Body: &ast.Block{
 					Statements: []ast.Stmt{
 						&ast.VarDec{
 							Name:  "a",
-							Value: &ast.IntLit{Value: 0},
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 0, Type: types.Int},
 						},
 						&ast.If{
 							Condition: &ast.Var{Name: "a"},
 							Then: &ast.Ret{
-								Value: &ast.IntLit{Value: 1},
+								Value: &ast.IntLit{Value: 1, Type: types.Int},
 							},
 							Else: &ast.Ret{
-								Value: &ast.IntLit{Value: 2},
+								Value: &ast.IntLit{Value: 2, Type: types.Int},
 							},
 						},
 					},
@@ -126,39 +996,43 @@ func TestAST(t *testing.T) {
 		Statements: []ast.Stmt{
 			&ast.FuncDec{
 				Name: "main",
+				Type: &types.Func{Result: types.Int},
 				Body: &ast.Block{
 					Statements: []ast.Stmt{
 						&ast.VarDec{
 							Name:  "a",
-							Value: &ast.IntLit{Value: 0},
+							Type:  types.Int,
+							Value: &ast.IntLit{Value: 0, Type: types.Int},
 						},
 						&ast.For{
-							Setup: &ast.ExprStmt{
-								Expr: &ast.Assign{
-									Var:   &ast.Var{Name: "a"},
-									Value: &ast.IntLit{Value: 0},
+							Setup: []ast.Stmt{
+								&ast.ExprStmt{
+									Expr: &ast.Assign{
+										Target: &ast.Var{Name: "a"},
+										Value:  &ast.IntLit{Value: 0, Type: types.Int},
+									},
 								},
 							},
 							Condition: &ast.BinaryOp{
 								Op:    "<",
 								Left:  &ast.Var{Name: "a"},
-								Right: &ast.IntLit{Value: 3},
+								Right: &ast.IntLit{Value: 3, Type: types.Int},
 							},
 							Increment: &ast.Assign{
-								Var: &ast.Var{Name: "a"},
+								Target: &ast.Var{Name: "a"},
 								Value: &ast.BinaryOp{
 									Op:    "+",
 									Left:  &ast.Var{Name: "a"},
-									Right: &ast.IntLit{Value: 1},
+									Right: &ast.IntLit{Value: 1, Type: types.Int},
 								},
 							},
 							Body: &ast.ExprStmt{
 								Expr: &ast.Assign{
-									Var: &ast.Var{Name: "a"},
+									Target: &ast.Var{Name: "a"},
 									Value: &ast.BinaryOp{
 										Op:    "*",
 										Left:  &ast.Var{Name: "a"},
-										Right: &ast.IntLit{Value: 2},
+										Right: &ast.IntLit{Value: 2, Type: types.Int},
 									},
 								},
 							},
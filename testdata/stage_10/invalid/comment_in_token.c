@@ -0,0 +1,3 @@
+int main() {
+    ret/**/urn 0;
+}
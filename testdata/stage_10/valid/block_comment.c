@@ -0,0 +1,7 @@
+/*
+ * Copyright (c) The Authors.
+ * Use of this source code is governed by a license.
+ */
+int main() {
+    return 1 /* one */ + /**/ 2;
+}
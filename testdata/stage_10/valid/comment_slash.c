@@ -0,0 +1,4 @@
+int main() {
+    /* a comment containing // and / and * and /* */
+    return 6 / 2; // 3
+}
@@ -0,0 +1,6 @@
+// Copyright (c) The Authors.
+// Use of this source code is governed by a license.
+int main() {
+    // the answer
+    return 2; // trailing comment
+}
@@ -0,0 +1,3 @@
+int main() {
+    return 18446744073709551616;
+}
@@ -0,0 +1,3 @@
+int main() {
+    return 2147483647 - 2147483640;
+}
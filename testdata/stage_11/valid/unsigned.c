@@ -0,0 +1,3 @@
+int main() {
+    return 10u + 20U;
+}
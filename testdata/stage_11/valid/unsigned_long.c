@@ -0,0 +1,3 @@
+int main() {
+    return 7UL + 1lu;
+}
@@ -0,0 +1,7 @@
+int puts(char *s);
+
+int main() {
+    char *a = "same";
+    char *b = "same";
+    return a == b;
+}
@@ -0,0 +1,6 @@
+int puts(char *s);
+
+int main() {
+    puts("");
+    puts("" "x" "");
+}
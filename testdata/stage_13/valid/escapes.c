@@ -0,0 +1,5 @@
+int puts(char *s);
+
+int main() {
+    puts("tab:\t quote:\" backslash:\\ octal:\101 hex:\x42\n");
+}
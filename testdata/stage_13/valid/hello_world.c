@@ -0,0 +1,5 @@
+int puts(char *s);
+
+int main() {
+    puts("Hello, World!");
+}
@@ -0,0 +1,3 @@
+& | ^ << >> ++ -- += -= *= /= %= &= |= ^= <<= >>= -> . [ ] ...
+&& || == != <= >= < > = + - * / % ~ ! ? : , ;
+a+++++b p->x s.y a<<=b>>=c a<<<b .... a&&&b a|||b a---b
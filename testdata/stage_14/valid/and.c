@@ -0,0 +1,3 @@
+int main() {
+    return 0xF0 & 0x3C;
+}
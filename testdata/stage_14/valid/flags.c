@@ -0,0 +1,10 @@
+int main() {
+    int flags = 0;
+    int READ = 1 << 0;
+    int WRITE = 1 << 1;
+    int EXEC = 1 << 2;
+    flags = flags | READ | EXEC;
+    flags = flags & ~READ;
+    flags = flags ^ WRITE;
+    return flags;
+}
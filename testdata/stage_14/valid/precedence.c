@@ -0,0 +1,3 @@
+int main() {
+    return 1 | 2 & 3 ^ 4;
+}
@@ -0,0 +1,3 @@
+int main() {
+    return -64 >> 2 == -16;
+}
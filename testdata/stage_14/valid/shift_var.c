@@ -0,0 +1,4 @@
+int main() {
+    int count = 3;
+    return 0x80 >> count;
+}
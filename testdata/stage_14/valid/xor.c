@@ -0,0 +1,3 @@
+int main() {
+    return 0xFF ^ 0x0F;
+}
@@ -0,0 +1,6 @@
+int main(void) {
+    int a[2];
+    int i = 0;
+    i += a;
+    return i;
+}
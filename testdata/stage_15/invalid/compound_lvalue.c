@@ -0,0 +1,5 @@
+int main() {
+    int a = 1;
+    3 += a;
+    return a;
+}
@@ -0,0 +1,7 @@
+int main(void) {
+    int a[2];
+    int *p = a;
+    int *q = a;
+    p += q;
+    return 0;
+}
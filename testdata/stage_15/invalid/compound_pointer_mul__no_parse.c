@@ -0,0 +1,6 @@
+int main(void) {
+    int a[2];
+    int *p = a;
+    p *= 2;
+    return 0;
+}
@@ -0,0 +1,6 @@
+int main(void) {
+    int a[2];
+    int *p = a + 1;
+    p -= a;
+    return 0;
+}
@@ -0,0 +1,4 @@
+int main() {
+    a += 1;
+    return 0;
+}
@@ -0,0 +1,9 @@
+int main() {
+    int a = 10;
+    a += 5;
+    a -= 3;
+    a *= 4;
+    a /= 6;
+    a %= 5;
+    return a;
+}
@@ -0,0 +1,9 @@
+int main() {
+    int a = 1;
+    a <<= 6;
+    a |= 3;
+    a &= 0x7E;
+    a ^= 0x10;
+    a >>= 1;
+    return a;
+}
@@ -0,0 +1,7 @@
+int main() {
+    int a = 2;
+    int b = 3;
+    int c = (a += b) * 2;
+    b *= a += 1;
+    return c + b;
+}
@@ -0,0 +1,6 @@
+int main() {
+    int a = 1;
+    int b = 2;
+    int c = a+++b;
+    return a * 10 + c;
+}
@@ -0,0 +1,5 @@
+int main() {
+    int a = 5;
+    int b = a++;
+    return b * 10 + a;
+}
@@ -0,0 +1,6 @@
+int main() {
+    int a = 3;
+    int b = -a++;
+    int c = !a--;
+    return a - b + c;
+}
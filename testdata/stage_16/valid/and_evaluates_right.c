@@ -0,0 +1,5 @@
+int putchar(int c);
+
+int main() {
+    return 1 && putchar('y');
+}
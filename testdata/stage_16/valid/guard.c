@@ -0,0 +1,8 @@
+int main() {
+    int d = 0;
+    int n = 10;
+    if (d != 0 && n / d > 1) {
+        return 1;
+    }
+    return d == 0 || n / d;
+}
@@ -0,0 +1,5 @@
+int putchar(int c);
+
+int main() {
+    return 0 || putchar('z');
+}
@@ -0,0 +1,11 @@
+int putchar(int c);
+
+int main() {
+    int a = 0;
+    int b = 0;
+    (a++ || b++) && putchar('1');
+    (a++ || b++) && putchar('2');
+    (a-- && b++) || putchar('3');
+    putchar('\n');
+    return a * 10 + b;
+}
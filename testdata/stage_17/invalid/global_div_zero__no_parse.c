@@ -0,0 +1,5 @@
+int x = 1 / 0;
+
+int main() {
+    return x;
+}
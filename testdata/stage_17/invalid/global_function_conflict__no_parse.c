@@ -0,0 +1,9 @@
+int foo = 3;
+
+int foo() {
+    return 0;
+}
+
+int main() {
+    return foo;
+}
@@ -0,0 +1,5 @@
+int x = 1
+
+int main() {
+    return x;
+}
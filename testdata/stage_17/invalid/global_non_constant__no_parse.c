@@ -0,0 +1,9 @@
+int f() {
+    return 1;
+}
+
+int x = f();
+
+int main() {
+    return x;
+}
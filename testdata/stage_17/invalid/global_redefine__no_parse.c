@@ -0,0 +1,6 @@
+int x = 1;
+int x = 2;
+
+int main() {
+    return x;
+}
@@ -0,0 +1,5 @@
+int main() {
+    return x;
+}
+
+int x = 1;
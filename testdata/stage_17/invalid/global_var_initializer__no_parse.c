@@ -0,0 +1,6 @@
+int a = 1;
+int b = a;
+
+int main() {
+    return b;
+}
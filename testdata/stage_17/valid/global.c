@@ -0,0 +1,5 @@
+int x = 5;
+
+int main() {
+    return x;
+}
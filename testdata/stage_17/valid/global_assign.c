@@ -0,0 +1,14 @@
+int counter;
+
+int inc() {
+    counter = counter + 1;
+    return counter;
+}
+
+int main() {
+    inc();
+    inc();
+    counter += 10;
+    counter++;
+    return inc();
+}
@@ -0,0 +1,8 @@
+int a = (1 << 4) | 3;
+int b = -2 * 3 + 10 % 4;
+int c = 1 ? 'a' : 'b';
+int d = 5 > 3 && 2 != 2;
+
+int main() {
+    return a + b + c + d;
+}
@@ -0,0 +1,11 @@
+int get();
+
+int main() {
+    return get();
+}
+
+int value = 42;
+
+int get() {
+    return value;
+}
@@ -0,0 +1,9 @@
+int n = 100;
+
+int twice(int n) {
+    return n * 2;
+}
+
+int main() {
+    return twice(4) + n;
+}
@@ -0,0 +1,12 @@
+int a = 3;
+
+int main() {
+    int ret = a;
+    int a = 4;
+    ret = ret * 10 + a;
+    {
+        int a = 5;
+        ret = ret * 10 + a;
+    }
+    return ret - 300;
+}
@@ -0,0 +1,10 @@
+int x = 7;
+
+int main(void) {
+    int r;
+    {
+        r = x;
+    }
+    int x = 3;
+    return r * 10 + x;
+}
@@ -0,0 +1,8 @@
+int x;
+int x;
+int x = 7;
+int x;
+
+int main() {
+    return x;
+}
@@ -0,0 +1,5 @@
+int x;
+
+int main() {
+    return x;
+}
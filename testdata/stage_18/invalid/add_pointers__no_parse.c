@@ -0,0 +1,5 @@
+int main() {
+    int a = 1;
+    int *p = &a;
+    return p + p;
+}
@@ -0,0 +1,4 @@
+int main() {
+    int *p = &3;
+    return 0;
+}
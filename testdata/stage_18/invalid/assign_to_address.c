@@ -0,0 +1,5 @@
+int main() {
+    int a = 1;
+    &a = 0;
+    return a;
+}
@@ -0,0 +1,4 @@
+int main() {
+    int * = 0;
+    return 0;
+}
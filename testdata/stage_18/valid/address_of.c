@@ -0,0 +1,5 @@
+int main() {
+    int a = 3;
+    int *p = &a;
+    return *p;
+}
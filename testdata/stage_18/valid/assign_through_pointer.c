@@ -0,0 +1,6 @@
+int main() {
+    int a = 1;
+    int *p = &a;
+    *p = 42;
+    return a;
+}
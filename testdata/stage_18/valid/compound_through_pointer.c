@@ -0,0 +1,9 @@
+int main() {
+    int a = 5;
+    int *p = &a;
+    *p += 10;
+    *p *= 2;
+    (*p)++;
+    --*p;
+    return a;
+}
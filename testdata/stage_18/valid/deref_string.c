@@ -0,0 +1,7 @@
+int putchar(int c);
+
+int main() {
+    putchar(*"hi");
+    putchar(*("hi" + 1));
+    return *("abc" + 2);
+}
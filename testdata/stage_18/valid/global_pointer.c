@@ -0,0 +1,7 @@
+int g = 7;
+int *p = &g;
+
+int main() {
+    *p = *p + 1;
+    return g;
+}
@@ -0,0 +1,8 @@
+int main() {
+    int a = 1;
+    int b = 2;
+    int *p = &a;
+    int *q = p - 1;
+    int *r = q + 1;
+    return (r == p) + (p - q) * 10 + (q - p + 1);
+}
@@ -0,0 +1,7 @@
+int main() {
+    int a = 1;
+    int b = 2;
+    int *p = &a;
+    int *q = p + 1;
+    return (p < q) + (q > p) * 2 + (p <= p) * 4 + (p != q) * 8 + (p >= q) * 16;
+}
@@ -0,0 +1,10 @@
+int main() {
+    int a = 1;
+    int *p = &a;
+    int *q = p;
+    q++;
+    ++q;
+    q += 3;
+    q -= 1;
+    return q - p;
+}
@@ -0,0 +1,10 @@
+int set(int *p, int v) {
+    *p = v;
+    return 0;
+}
+
+int main() {
+    int a = 0;
+    set(&a, 17);
+    return a;
+}
@@ -0,0 +1,7 @@
+int main() {
+    int a = 5;
+    int *p = &a;
+    int **pp = &p;
+    **pp = 9;
+    return a + *p;
+}
@@ -0,0 +1,13 @@
+int swap(int *a, int *b) {
+    int tmp = *a;
+    *a = *b;
+    *b = tmp;
+    return 0;
+}
+
+int main() {
+    int x = 3;
+    int y = 8;
+    swap(&x, &y);
+    return x * 10 + y;
+}
@@ -0,0 +1,6 @@
+int main() {
+    int a[2];
+    int b[2];
+    a = b;
+    return 0;
+}
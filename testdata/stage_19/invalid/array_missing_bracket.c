@@ -0,0 +1,4 @@
+int main() {
+    int a[2;
+    return 0;
+}
@@ -0,0 +1,10 @@
+int main() {
+    int m[2][3] = {{1, 2, 3}, {4, 5, 6}};
+    int total = 0;
+    for (int i = 0; i < 2; i++) {
+        for (int j = 0; j < 3; j++) {
+            total = total * 2 + m[i][j];
+        }
+    }
+    return total;
+}
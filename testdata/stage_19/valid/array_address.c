@@ -0,0 +1,9 @@
+int main() {
+    int a[4] = {1, 2, 3, 4};
+    int *end = &a[4];
+    int count = 0;
+    for (int *p = &a[0]; p < end; p++) {
+        count += *p;
+    }
+    return count + (end - a);
+}
@@ -0,0 +1,8 @@
+int main() {
+    int a[2] = {5, 10};
+    int i = 0;
+    a[i++] += 3;
+    a[i] *= 2;
+    --a[0];
+    return a[0] + a[1] + i;
+}
@@ -0,0 +1,13 @@
+int sum(int *p, int n) {
+    int total = 0;
+    for (int i = 0; i < n; i++) {
+        total += p[i];
+    }
+    return total;
+}
+
+int main() {
+    int a[] = {1, 2, 3, 4, 5};
+    int *p = a;
+    return sum(a, 5) + *(p + 1) + *a;
+}
@@ -0,0 +1,8 @@
+int primes[] = {2, 3, 5, 7};
+int zeros[8];
+int *second = primes + 1;
+
+int main() {
+    zeros[7] = 9;
+    return primes[3] * 10 + zeros[7] + zeros[0] + *second;
+}
@@ -0,0 +1,4 @@
+int main() {
+    int a[3] = {1, 2, 3};
+    return 2[a] + a[1]++ + a[1];
+}
@@ -0,0 +1,4 @@
+int main() {
+    int a[] = {4, 5, 6};
+    return a[0] * 100 + a[1] * 10 + a[2];
+}
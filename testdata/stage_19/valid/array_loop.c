@@ -0,0 +1,11 @@
+int main() {
+    int a[10];
+    int sum = 0;
+    for (int i = 0; i < 7; i++) {
+        a[i] = i * i;
+    }
+    for (int i = 0; i < 7; i++) {
+        sum += a[i];
+    }
+    return sum;
+}
@@ -0,0 +1,12 @@
+int first(int a[]) {
+    return a[0];
+}
+
+int second(int a[3]) {
+    return a[1];
+}
+
+int main() {
+    int a[3] = {11, 22, 33};
+    return first(a) + second(a);
+}
@@ -0,0 +1,4 @@
+int main() {
+    int a[4] = {7, 8};
+    return a[0] + a[1] + a[2] + a[3];
+}
@@ -0,0 +1,9 @@
+int putchar(int c);
+
+int main() {
+    int s[] = {'o', 'k', '\n'};
+    for (int i = 0; i < 3; i++) {
+        putchar(s[i]);
+    }
+    return "abc"[1];
+}
@@ -0,0 +1,9 @@
+struct point {
+    int x;
+};
+
+int main() {
+    struct point p;
+    struct point *q = &p;
+    return q.x;
+}
@@ -0,0 +1,8 @@
+struct point {
+    int x;
+    int x;
+};
+
+int main() {
+    return 0;
+}
@@ -0,0 +1,14 @@
+struct a {
+    int x;
+};
+
+struct b {
+    int x;
+};
+
+int main() {
+    struct a x;
+    struct b y;
+    x = y;
+    return 0;
+}
@@ -0,0 +1,6 @@
+struct unknown;
+
+int main() {
+    struct unknown u;
+    return 0;
+}
@@ -0,0 +1,7 @@
+struct node {
+    struct node next;
+};
+
+int main() {
+    return 0;
+}
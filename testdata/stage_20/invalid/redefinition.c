@@ -0,0 +1,11 @@
+struct point {
+    int x;
+};
+
+struct point {
+    int y;
+};
+
+int main() {
+    return 0;
+}
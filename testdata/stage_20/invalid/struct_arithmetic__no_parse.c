@@ -0,0 +1,9 @@
+struct s {
+    int x;
+};
+
+int main() {
+    struct s a;
+    struct s b;
+    return a + b;
+}
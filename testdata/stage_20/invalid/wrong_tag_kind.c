@@ -0,0 +1,8 @@
+struct s {
+    int x;
+};
+
+int main() {
+    union s u;
+    return 0;
+}
@@ -0,0 +1,9 @@
+int main() {
+    struct {
+        int a;
+        int b;
+    } s;
+    s.a = 2;
+    s.b = 3;
+    return s.a * s.b;
+}
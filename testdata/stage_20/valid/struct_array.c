@@ -0,0 +1,14 @@
+struct item {
+    int id;
+    int qty;
+};
+
+int main() {
+    struct item items[3] = {{1, 10}, {2, 20}, {3, 30}};
+    int total = 0;
+    for (int i = 0; i < 3; i++) {
+        total += items[i].id * items[i].qty;
+    }
+    struct item *last = &items[2];
+    return total - last->qty;
+}
@@ -0,0 +1,16 @@
+struct counter {
+    int count;
+    int step;
+};
+
+int bump(struct counter *c) {
+    c->count += c->step;
+    return c->count;
+}
+
+int main() {
+    struct counter c = {0, 5};
+    bump(&c);
+    bump(&c);
+    return c.count;
+}
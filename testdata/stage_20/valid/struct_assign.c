@@ -0,0 +1,13 @@
+struct pair {
+    int a;
+    int b;
+};
+
+int main() {
+    struct pair p = {6, 7};
+    struct pair q;
+    q = p;
+    p.a = 0;
+    struct pair r = q;
+    return q.a * 10 + r.b + p.a;
+}
@@ -0,0 +1,21 @@
+struct b;
+
+struct a {
+    int value;
+    struct b *other;
+};
+
+struct b {
+    int value;
+    struct a *other;
+};
+
+int main() {
+    struct a x;
+    struct b y;
+    x.value = 1;
+    y.value = 2;
+    x.other = &y;
+    y.other = &x;
+    return x.other->other->other->value;
+}
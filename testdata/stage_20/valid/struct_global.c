@@ -0,0 +1,13 @@
+struct config {
+    int width;
+    int height;
+};
+
+struct config defaults = {80, 24};
+struct config current;
+
+int main() {
+    current = defaults;
+    current.height = current.height + 1;
+    return current.width + current.height + defaults.height;
+}
@@ -0,0 +1,10 @@
+struct point {
+    int x;
+    int y;
+    int z;
+};
+
+int main() {
+    struct point p = {1, 2};
+    return p.x + p.y * 10 + p.z * 100;
+}
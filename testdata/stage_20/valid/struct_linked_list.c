@@ -0,0 +1,20 @@
+struct node {
+    int value;
+    struct node *next;
+};
+
+int sum(struct node *n) {
+    int total = 0;
+    while (n) {
+        total += n->value;
+        n = n->next;
+    }
+    return total;
+}
+
+int main() {
+    struct node c = {3, 0};
+    struct node b = {2, &c};
+    struct node a = {1, &b};
+    return sum(&a) + a.next->next->value * 10;
+}
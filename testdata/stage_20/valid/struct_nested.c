@@ -0,0 +1,18 @@
+struct point {
+    int x;
+    int y;
+};
+
+struct rect {
+    struct point min;
+    struct point max;
+};
+
+int area(struct rect *r) {
+    return (r->max.x - r->min.x) * (r->max.y - r->min.y);
+}
+
+int main() {
+    struct rect r = {{1, 2}, {4, 8}};
+    return area(&r);
+}
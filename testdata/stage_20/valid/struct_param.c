@@ -0,0 +1,15 @@
+struct pair {
+    int a;
+    int b;
+};
+
+int diff(int scale, struct pair p, int offset) {
+    p.a = p.a * scale;
+    return p.a - p.b + offset;
+}
+
+int main() {
+    struct pair p = {5, 3};
+    int d = diff(4, p, 1);
+    return d * 10 + p.a;
+}
@@ -0,0 +1,16 @@
+struct s {
+    int a;
+};
+
+int main() {
+    struct s outer = {1};
+    {
+        struct s {
+            int a;
+            int b;
+        };
+        struct s inner = {2, 3};
+        outer.a = outer.a + inner.b;
+    }
+    return outer.a;
+}
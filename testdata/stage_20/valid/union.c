@@ -0,0 +1,19 @@
+union value {
+    int i;
+    int *p;
+};
+
+struct tagged {
+    int kind;
+    union value v;
+};
+
+int main() {
+    int x = 9;
+    struct tagged t;
+    t.kind = 1;
+    t.v.p = &x;
+    int ok = *t.v.p;
+    t.v.i = 5;
+    return ok * 10 + t.v.i;
+}
@@ -0,0 +1,6 @@
+enum { A };
+
+int main() {
+    int *p = &A;
+    return 0;
+}
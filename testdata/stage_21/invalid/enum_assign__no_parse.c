@@ -0,0 +1,6 @@
+enum { A };
+
+int main() {
+    A = 1;
+    return 0;
+}
@@ -0,0 +1,6 @@
+int A;
+enum { A };
+
+int main() {
+    return 0;
+}
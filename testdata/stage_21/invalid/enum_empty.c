@@ -0,0 +1,5 @@
+enum e {};
+
+int main() {
+    return 0;
+}
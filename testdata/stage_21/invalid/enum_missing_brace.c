@@ -0,0 +1,5 @@
+enum { A, B;
+
+int main() {
+    return 0;
+}
@@ -0,0 +1,5 @@
+int main() {
+    int x = 1;
+    enum { A = x };
+    return A;
+}
@@ -0,0 +1,6 @@
+enum { A, B };
+enum { B, C };
+
+int main() {
+    return 0;
+}
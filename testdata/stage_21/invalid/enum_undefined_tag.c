@@ -0,0 +1,4 @@
+int main() {
+    enum missing m;
+    return 0;
+}
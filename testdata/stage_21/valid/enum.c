@@ -0,0 +1,5 @@
+enum color { RED, GREEN = 5, BLUE };
+
+int main() {
+    return RED + GREEN * 10 + BLUE;
+}
@@ -0,0 +1,12 @@
+enum { SIZE = 4, LAST = SIZE - 1 };
+
+int values[SIZE * 2];
+
+int main() {
+    int local[SIZE];
+    for (int i = 0; i < SIZE; i++) {
+        local[i] = i;
+        values[i * 2] = i * 2;
+    }
+    return local[LAST] + values[LAST * 2];
+}
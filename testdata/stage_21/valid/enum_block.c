@@ -0,0 +1,9 @@
+int main() {
+    int total = 0;
+    enum { ONE = 1, TWO, THREE };
+    {
+        enum { ONE = 10 };
+        total = total + ONE;
+    }
+    return total + ONE + THREE;
+}
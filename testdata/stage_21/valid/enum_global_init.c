@@ -0,0 +1,7 @@
+enum flags { A = 1 << 0, B = 1 << 1, C = 1 << 2 };
+
+int mask = A | C;
+
+int main() {
+    return mask + B * 10;
+}
@@ -0,0 +1,11 @@
+struct shape {
+    enum { CIRCLE, SQUARE } kind;
+    int size;
+};
+
+int main() {
+    struct shape s;
+    s.kind = SQUARE;
+    s.size = 4;
+    return s.kind * s.size;
+}
@@ -0,0 +1,5 @@
+enum { LOW = -3, MID, HIGH = LOW + 10 };
+
+int main() {
+    return MID + HIGH;
+}
@@ -0,0 +1,7 @@
+int A = 5;
+
+int main(void) {
+    int x = A;
+    enum { A = 1 };
+    return x;
+}
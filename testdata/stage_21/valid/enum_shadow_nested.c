@@ -0,0 +1,11 @@
+int A = 5;
+
+int main(void) {
+    int x;
+    {
+        x = A;
+    }
+    enum { A = 3 };
+    int a[A];
+    return x + sizeof(a) / sizeof(a[0]);
+}
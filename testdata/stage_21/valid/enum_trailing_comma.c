@@ -0,0 +1,10 @@
+enum state {
+    IDLE,
+    RUNNING,
+    DONE,
+};
+
+int main() {
+    enum state s = RUNNING;
+    return s;
+}
@@ -0,0 +1,11 @@
+enum color { RED, GREEN, BLUE };
+
+int next(enum color c) {
+    return (c + 1) % 3;
+}
+
+int main() {
+    enum color c = BLUE;
+    c = next(c);
+    return c == RED;
+}
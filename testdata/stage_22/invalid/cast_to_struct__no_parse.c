@@ -0,0 +1,8 @@
+typedef struct {
+    int x;
+} S;
+
+int main() {
+    S s = (S)1;
+    return 0;
+}
@@ -0,0 +1,5 @@
+typedef int T;
+
+int main() {
+    return T;
+}
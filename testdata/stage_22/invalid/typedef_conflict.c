@@ -0,0 +1,6 @@
+typedef int T;
+typedef int *T;
+
+int main() {
+    return 0;
+}
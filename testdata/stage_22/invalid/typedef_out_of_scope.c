@@ -0,0 +1,7 @@
+int main() {
+    {
+        typedef int local;
+    }
+    local x = 1;
+    return x;
+}
@@ -0,0 +1,7 @@
+typedef int T;
+
+int main() {
+    int T = 1;
+    T x = 2;
+    return x;
+}
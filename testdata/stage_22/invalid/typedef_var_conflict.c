@@ -0,0 +1,6 @@
+typedef int T;
+int T;
+
+int main() {
+    return 0;
+}
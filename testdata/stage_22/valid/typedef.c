@@ -0,0 +1,7 @@
+typedef int myint;
+
+int main() {
+    myint x = 3;
+    myint y = x * 4;
+    return y;
+}
@@ -0,0 +1,10 @@
+typedef int triple[3];
+
+int sum(int *values) {
+    return values[0] + values[1] + values[2];
+}
+
+int main() {
+    triple t = {4, 5, 6};
+    return sum(t);
+}
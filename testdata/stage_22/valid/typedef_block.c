@@ -0,0 +1,11 @@
+int main() {
+    typedef int local;
+    local x = 4;
+    {
+        typedef int *local;
+        local p = &x;
+        *p = 6;
+    }
+    local y = x;
+    return y;
+}
@@ -0,0 +1,8 @@
+typedef int number;
+typedef int *pointer;
+
+int main() {
+    int x = 7;
+    int *p = (pointer)&x;
+    return (number)*p + (number)(x * 2);
+}
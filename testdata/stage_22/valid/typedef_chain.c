@@ -0,0 +1,9 @@
+typedef int a;
+typedef a b;
+typedef b *c;
+
+int main() {
+    b value = 12;
+    c p = &value;
+    return *p;
+}
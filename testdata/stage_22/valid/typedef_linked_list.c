@@ -0,0 +1,21 @@
+typedef struct node node;
+
+struct node {
+    int value;
+    node *next;
+};
+
+int length(node *n) {
+    int count = 0;
+    for (; n; n = n->next) {
+        count++;
+    }
+    return count;
+}
+
+int main() {
+    node c = {3, 0};
+    node b = {2, &c};
+    node a = {1, &b};
+    return length(&a);
+}
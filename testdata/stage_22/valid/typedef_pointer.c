@@ -0,0 +1,13 @@
+typedef int *intptr;
+
+int set(intptr p) {
+    *p = 21;
+    return 0;
+}
+
+int main() {
+    int x = 0;
+    intptr p = &x;
+    set(p);
+    return x * 2;
+}
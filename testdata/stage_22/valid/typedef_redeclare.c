@@ -0,0 +1,7 @@
+typedef int T;
+typedef int T;
+
+int main() {
+    T x = 1;
+    return x;
+}
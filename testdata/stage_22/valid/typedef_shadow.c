@@ -0,0 +1,11 @@
+typedef int T;
+
+int main() {
+    T a = 2;
+    {
+        int T = 5;
+        a = a * T;
+    }
+    T b = a + 1;
+    return b;
+}
@@ -0,0 +1,9 @@
+typedef int T;
+
+int main(void) {
+    int y = 0;
+    for (int T = 2; T < 4; T++)
+        y = y + T;
+    T x = y;
+    return x;
+}
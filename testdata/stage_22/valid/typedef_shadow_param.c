@@ -0,0 +1,10 @@
+typedef int T;
+
+int twice(int T) {
+    return T * 2;
+}
+
+int main() {
+    T x = twice(4);
+    return x;
+}
@@ -0,0 +1,19 @@
+typedef struct point {
+    int x;
+    int y;
+} point;
+
+typedef struct {
+    point min;
+    point max;
+} rect;
+
+int width(rect *r) {
+    return r->max.x - r->min.x;
+}
+
+int main() {
+    rect r = {{1, 2}, {9, 5}};
+    struct point p = r.max;
+    return width(&r) * 10 + p.y;
+}
@@ -0,0 +1,4 @@
+int main() {
+    _Bool int x = 1;
+    return x;
+}
@@ -0,0 +1,4 @@
+int main() {
+    long long long x = 1;
+    return x;
+}
@@ -0,0 +1,4 @@
+int main() {
+    long short x = 1;
+    return x;
+}
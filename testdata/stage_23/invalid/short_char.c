@@ -0,0 +1,4 @@
+int main() {
+    short char x = 1;
+    return x;
+}
@@ -0,0 +1,4 @@
+int main() {
+    signed unsigned x = 1;
+    return x;
+}
@@ -0,0 +1,8 @@
+int main() {
+    _Bool a = 42;
+    _Bool b = 0;
+    _Bool c = (_Bool)256;
+    b++;
+    a--;
+    return a + b * 2 + c * 4;
+}
@@ -0,0 +1,6 @@
+int main() {
+    char c = 200;
+    unsigned char u = 300;
+    signed char s = -1;
+    return (c < 0) + u + (s == -1);
+}
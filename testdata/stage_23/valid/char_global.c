@@ -0,0 +1,8 @@
+char c = 300;
+unsigned short s = -1;
+_Bool b = 7;
+short values[3] = {1, -2, 3};
+
+int main() {
+    return c + (s == 65535) + b * 2 + values[0] + values[1] + values[2];
+}
@@ -0,0 +1,11 @@
+char narrow(int x) {
+    return x;
+}
+
+int widen(unsigned char c) {
+    return c;
+}
+
+int main() {
+    return narrow(300) + widen(257) + widen(narrow(-1)) - 250;
+}
@@ -0,0 +1,12 @@
+int main() {
+    char buf[4] = {1, 2, 3, 4};
+    char *p = buf;
+    int sum = 0;
+    while (p < buf + 4) {
+        sum = sum * 2 + *p;
+        p++;
+    }
+    int word = 0x01020304;
+    unsigned char *b = (unsigned char *)&word;
+    return sum + b[0];
+}
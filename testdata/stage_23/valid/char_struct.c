@@ -0,0 +1,14 @@
+struct mixed {
+    char a;
+    int b;
+    char c;
+    short d;
+};
+
+int main() {
+    struct mixed m;
+    struct mixed ms[2];
+    char *base = (char *)&m;
+    int offsets = ((char *)&m.b - base) * 10 + ((char *)&m.d - base);
+    return offsets + ((char *)&ms[1] - (char *)&ms[0]) * 2;
+}
@@ -0,0 +1,8 @@
+int main() {
+    unsigned char a = 200;
+    unsigned char b = 100;
+    char c = 127;
+    int sum = a + b;
+    c++;
+    return sum - 250 + (c == -128);
+}
@@ -0,0 +1,7 @@
+int main() {
+    short s = 40000;
+    unsigned short u = 65535;
+    u++;
+    short int t = -2;
+    return (s < 0) + (u == 0) * 2 + (t == -2) * 4;
+}
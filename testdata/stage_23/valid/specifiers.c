@@ -0,0 +1,11 @@
+int main() {
+    long a = 1;
+    long int b = 2;
+    unsigned long c = 3;
+    long unsigned int d = 4;
+    signed e = 5;
+    int signed f = 6;
+    unsigned g = 7;
+    char unsigned h = 8;
+    return a + b + c + d + e + f + g + h;
+}
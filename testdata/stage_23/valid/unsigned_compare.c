@@ -0,0 +1,5 @@
+int main() {
+    unsigned x = 0;
+    int y = -1;
+    return (x - 1 > 5) + (y < 1) * 2 + (y < 1u) * 4 + (y > 0u) * 8;
+}
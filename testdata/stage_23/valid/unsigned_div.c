@@ -0,0 +1,6 @@
+int main() {
+    unsigned x = -8;
+    int y = -7;
+    unsigned r = x % 5;
+    return (x / 2 > 1000000) + (y / 2 == -3) * 2 + (y % 2 == -1) * 4 + r * 8;
+}
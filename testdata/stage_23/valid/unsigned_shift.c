@@ -0,0 +1,6 @@
+int main() {
+    unsigned x = 1 << 31;
+    int y = -16;
+    x >>= 28;
+    return x + (y >> 2 == -4) * 16;
+}
@@ -0,0 +1,7 @@
+int something(void) {
+    return;
+}
+
+int main(void) {
+    return something();
+}
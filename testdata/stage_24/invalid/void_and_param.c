@@ -0,0 +1,7 @@
+int f(void, int x) {
+    return x;
+}
+
+int main(void) {
+    return 0;
+}
@@ -0,0 +1,6 @@
+void nothing(void) {
+}
+
+int main(void) {
+    return nothing() + 1;
+}
@@ -0,0 +1,7 @@
+int f(void x) {
+    return 1;
+}
+
+int main(void) {
+    return 0;
+}
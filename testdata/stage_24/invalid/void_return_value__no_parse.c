@@ -0,0 +1,8 @@
+void nothing(void) {
+    return 1;
+}
+
+int main(void) {
+    nothing();
+    return 0;
+}
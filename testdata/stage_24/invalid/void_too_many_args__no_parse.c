@@ -0,0 +1,7 @@
+int f(void) {
+    return 1;
+}
+
+int main(void) {
+    return f(1);
+}
@@ -0,0 +1,7 @@
+void nothing(void) {
+}
+
+int main(void) {
+    int x = nothing();
+    return x;
+}
@@ -0,0 +1,4 @@
+int main(void) {
+    void x;
+    return 0;
+}
@@ -0,0 +1,15 @@
+int calls = 0;
+
+int touch() {
+    calls++;
+    return 100;
+}
+
+int main(void) {
+    int unused = 1;
+    (void)unused;
+    (void)touch();
+    for (unused = 0; unused < 3; (void)touch())
+        unused++;
+    return calls;
+}
@@ -0,0 +1,11 @@
+int counter = 0;
+
+void bump(int n) {
+    counter = counter + n;
+}
+
+int main(void) {
+    bump(3);
+    bump(4);
+    return counter;
+}
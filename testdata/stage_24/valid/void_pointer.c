@@ -0,0 +1,14 @@
+void fill(void *dst, int n) {
+    char *p = dst;
+    while (n > 0) {
+        n--;
+        p[n] = n;
+    }
+}
+
+int main(void) {
+    char buf[4];
+    void *v = buf;
+    fill(v, 4);
+    return buf[1] + buf[3] * 2 + ((char *)v == buf) * 10;
+}
@@ -0,0 +1,16 @@
+void set(int *p, int v);
+int get(void);
+
+int main(void) {
+    int x = 0;
+    set(&x, 9);
+    return x + get();
+}
+
+void set(int *p, int v) {
+    *p = v;
+}
+
+int get(void) {
+    return 2;
+}
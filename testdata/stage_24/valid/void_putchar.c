@@ -0,0 +1,12 @@
+int putchar(int c);
+
+void say(char c) {
+    putchar(c);
+    putchar(10);
+}
+
+int main(void) {
+    say(72);
+    say(105);
+    return 0;
+}
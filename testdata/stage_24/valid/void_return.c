@@ -0,0 +1,14 @@
+int total = 0;
+
+void add_positive(int n) {
+    if (n < 0)
+        return;
+    total += n;
+}
+
+int main(void) {
+    add_positive(5);
+    add_positive(-3);
+    add_positive(7);
+    return total;
+}
@@ -0,0 +1,8 @@
+int main(void) {
+    int x = 2;
+    switch (x) {
+    case 1, 2:
+        return 1;
+    }
+    return 0;
+}
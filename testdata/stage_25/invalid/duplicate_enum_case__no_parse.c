@@ -0,0 +1,11 @@
+enum { A = 3, B = 3 };
+
+int main(void) {
+    switch (1) {
+    case A:
+        return 1;
+    case B:
+        return 2;
+    }
+    return 0;
+}
@@ -0,0 +1,8 @@
+int main(void) {
+    switch (1) {
+    default:
+        return 1;
+    default:
+        return 2;
+    }
+}
@@ -0,0 +1,8 @@
+int main(void) {
+    int x = 1;
+    switch (1) {
+    case x:
+        return 1;
+    }
+    return 0;
+}
@@ -0,0 +1,7 @@
+int main(void) {
+    switch 1 {
+    case 1:
+        return 1;
+    }
+    return 0;
+}
@@ -0,0 +1,12 @@
+int main(void) {
+    int n = 2;
+    switch (n) {
+    case 1: {
+        int x;
+    case 2:
+        x = 5;
+        return (1 + 2) + x;
+    }
+    }
+    return 0;
+}
@@ -0,0 +1,22 @@
+int vowel(char c) {
+    switch (c) {
+    case 'a':
+    case 'e':
+    case 'i':
+    case 'o':
+    case 'u':
+        return 1;
+    default:
+        return 0;
+    }
+}
+
+int main(void) {
+    char *s = "the quick brown fox";
+    int n = 0;
+    while (*s) {
+        n += vowel(*s);
+        s++;
+    }
+    return n;
+}
@@ -0,0 +1,33 @@
+int days(int month) {
+    int n;
+    switch (month) {
+    case 2:
+        n = 28;
+        break;
+    case 4:
+    case 6:
+    case 9:
+    case 11:
+        n = 30;
+        break;
+    case 1:
+    case 3:
+    case 5:
+    case 7:
+    case 8:
+    case 10:
+    case 12:
+        n = 31;
+        break;
+    default:
+        n = 0;
+    }
+    return n;
+}
+
+int main(void) {
+    int total = 0;
+    for (int m = 0; m <= 13; m++)
+        total += days(m);
+    return total - 300;
+}
@@ -0,0 +1,21 @@
+enum op { ADD, SUB, MUL, DIV, NEG };
+
+int eval(enum op o, int a, int b) {
+    switch (o) {
+    case ADD:
+        return a + b;
+    case SUB:
+        return a - b;
+    case MUL:
+        return a * b;
+    case DIV:
+        return a / b;
+    case NEG:
+        return -a;
+    }
+    return 0;
+}
+
+int main(void) {
+    return eval(ADD, 2, 3) + eval(SUB, 9, 4) + eval(MUL, 3, 4) + eval(DIV, 20, 5) + eval(NEG, -6, 0);
+}
@@ -0,0 +1,17 @@
+int main(void) {
+    int x = 0;
+    switch (2) {
+    case 1:
+        x += 1;
+    case 2:
+        x += 2;
+    case 3:
+        x += 4;
+    case 4:
+        x += 8;
+        break;
+    case 5:
+        x += 16;
+    }
+    return x;
+}
@@ -0,0 +1,21 @@
+int main(void) {
+    int r = 0;
+    for (int i = 0; i < 4; i++) {
+        switch (i) {
+            int scratch;
+        case 0:
+            scratch = 5;
+            r += scratch;
+            break;
+        case 1: {
+            int t = 7;
+            r += t;
+            break;
+        }
+        default:
+            scratch = i * 10;
+            r += scratch;
+        }
+    }
+    return r;
+}
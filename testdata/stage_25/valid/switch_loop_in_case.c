@@ -0,0 +1,16 @@
+int main(void) {
+    int n = 0;
+    switch (3) {
+    case 3:
+        while (1) {
+            n++;
+            if (n == 4)
+                break;
+        }
+        n += 10;
+        break;
+    case 4:
+        n = 99;
+    }
+    return n;
+}
@@ -0,0 +1,19 @@
+int f(int a, int b) {
+    switch (a) {
+    case 0:
+        switch (b) {
+        case 0:
+            return 1;
+        case 1:
+            return 2;
+        }
+        return 3;
+    case 1:
+        return 4;
+    }
+    return 5;
+}
+
+int main(void) {
+    return f(0, 0) + f(0, 1) * 10 + f(0, 9) * 20 + f(1, 0) + f(7, 7) * 2;
+}
@@ -0,0 +1,12 @@
+int main(void) {
+    int x = 5;
+    switch (x) {
+    case 1:
+        x = 100;
+        break;
+    case 2:
+        x = 200;
+        break;
+    }
+    return x;
+}
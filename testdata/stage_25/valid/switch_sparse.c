@@ -0,0 +1,16 @@
+int classify(int x) {
+    switch (x) {
+    case 1:
+        return 10;
+    case 100:
+        return 20;
+    case -5:
+        return 30;
+    default:
+        return 40;
+    }
+}
+
+int main(void) {
+    return classify(1) + classify(100) + classify(-5) + classify(7);
+}
@@ -0,0 +1,8 @@
+int main(void) {
+here:
+    ;
+    {
+    here:
+        return 1;
+    }
+}
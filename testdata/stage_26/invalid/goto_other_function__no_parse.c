@@ -0,0 +1,8 @@
+int f(void) {
+target:
+    return 1;
+}
+
+int main(void) {
+    goto target;
+}
@@ -0,0 +1,7 @@
+int main(void) {
+    int x = 1;
+    goto skip;
+    x = 100;
+skip:
+    return x + 2;
+}
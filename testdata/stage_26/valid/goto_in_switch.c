@@ -0,0 +1,18 @@
+int main(void) {
+    int n = 0;
+again:
+    switch (n) {
+    case 0:
+    case 1:
+        n++;
+        goto again;
+    case 2:
+        n += 5;
+    retry:
+        if (n < 9) {
+            n++;
+            goto retry;
+        }
+    }
+    return n;
+}
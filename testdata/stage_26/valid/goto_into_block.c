@@ -0,0 +1,9 @@
+int main(void) {
+    goto inside;
+    {
+        int x;
+    inside:
+        x = 5;
+        return (1 + 2) + x;
+    }
+}
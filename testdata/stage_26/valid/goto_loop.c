@@ -0,0 +1,11 @@
+int main(void) {
+    int i = 0;
+    int sum = 0;
+top:
+    if (i < 10) {
+        sum += i;
+        i++;
+        goto top;
+    }
+    return sum;
+}
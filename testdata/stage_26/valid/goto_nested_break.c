@@ -0,0 +1,13 @@
+int main(void) {
+    int found = 0;
+    for (int i = 0; i < 10; i++) {
+        for (int j = 0; j < 10; j++) {
+            if (i * j == 42) {
+                found = i * 10 + j;
+                goto done;
+            }
+        }
+    }
+done:
+    return found;
+}
@@ -0,0 +1,12 @@
+int main(void) {
+    int tries = 0;
+retry:
+    {
+        int buf[256];
+        buf[255] = tries;
+        tries = buf[255] + 1;
+        if (tries < 100000)
+            goto retry;
+    }
+    return tries % 256;
+}
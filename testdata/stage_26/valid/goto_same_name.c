@@ -0,0 +1,20 @@
+int f(int x) {
+    if (x)
+        goto out;
+    return 1;
+out:
+    return 2;
+}
+
+int g(int x) {
+    if (x)
+        goto out;
+    return 3;
+out:
+    return 4;
+}
+
+int main(void) {
+    int out = 10;
+    return f(0) + f(1) + g(0) + g(1) + out;
+}
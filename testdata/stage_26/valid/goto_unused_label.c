@@ -0,0 +1,4 @@
+int main(void) {
+unused:
+    return 6;
+}
@@ -0,0 +1,5 @@
+int x = (1, 2);
+
+int main(void) {
+    return x;
+}
@@ -0,0 +1,7 @@
+void nothing(void) {
+}
+
+int main(void) {
+    int x = (1, nothing());
+    return x;
+}
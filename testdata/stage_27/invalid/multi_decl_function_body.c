@@ -0,0 +1,3 @@
+int x, f(void) {
+    return 1;
+}
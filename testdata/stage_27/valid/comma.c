@@ -0,0 +1,7 @@
+int main(void) {
+    int a;
+    int b;
+    a = 1, b = 2;
+    int c = (a += 3, b * a);
+    return c;
+}
@@ -0,0 +1,8 @@
+int sub(int a, int b) {
+    return a - b;
+}
+
+int main(void) {
+    int x = 1;
+    return sub((x = 20, x + 5), 3) + sub(x, (1, 2));
+}
@@ -0,0 +1,8 @@
+int main(void) {
+    int i;
+    int j;
+    int sum = 0;
+    for (i = 0, j = 10; i < j; i++, j--)
+        sum += j - i;
+    return sum;
+}
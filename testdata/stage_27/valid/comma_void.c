@@ -0,0 +1,10 @@
+int count = 0;
+
+void tick(void) {
+    count++;
+}
+
+int main(void) {
+    tick(), tick(), tick();
+    return (tick(), count);
+}
@@ -0,0 +1,6 @@
+int main(void) {
+    int a = 1, b, c = 3;
+    b = a + c;
+    int *p = &b, q = 10;
+    return a + b + c + *p + q;
+}
@@ -0,0 +1,6 @@
+int main(void) {
+    int sum = 0;
+    for (int i = 0, j = 5; i < j; i++, j--)
+        sum = sum * 10 + i * j;
+    return sum % 256;
+}
@@ -0,0 +1,16 @@
+int a = 4, b, *p = &a;
+int arr[3] = {1, 2, 3}, n = 3;
+int twice(int x), zero(void);
+
+int twice(int x) {
+    return x * 2;
+}
+
+int zero(void) {
+    return 0;
+}
+
+int main(void) {
+    b = twice(*p);
+    return a + b + arr[n - 1] + zero();
+}
@@ -0,0 +1,4 @@
+int main(void) {
+    int a = 2, b = a * 3, c = a + b;
+    return c;
+}
@@ -0,0 +1,8 @@
+typedef int num, *num_ptr;
+
+int main(void) {
+    num x = 9;
+    num_ptr p = &x;
+    char a = 1, buf[4] = {1, 2, 3, 4}, *s = buf;
+    return *p + a + s[3];
+}
@@ -0,0 +1,5 @@
+struct missing;
+
+int main(void) {
+    return sizeof(struct missing);
+}
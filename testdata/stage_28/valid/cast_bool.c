@@ -0,0 +1,5 @@
+int main(void) {
+    int x = 256;
+    int *p = &x;
+    return (_Bool)x + (_Bool)0 + (_Bool)p * 2 + (_Bool)(char)x * 4;
+}
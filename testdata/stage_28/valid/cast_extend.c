@@ -0,0 +1,8 @@
+int main(void) {
+    char c = -3;
+    unsigned char u = 250;
+    int a = (int)c;
+    unsigned b = (unsigned)c;
+    int d = (int)u;
+    return a + (b > 1000) + d;
+}
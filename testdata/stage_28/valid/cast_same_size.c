@@ -0,0 +1,9 @@
+int main(void) {
+    int x = -1;
+    unsigned u = (unsigned)x;
+    int back = (int)u;
+    int *p = &x;
+    int addr = (int)p;
+    int *q = (int *)addr;
+    return (u > 0) + (back == -1) * 2 + (*q == -1) * 4;
+}
@@ -0,0 +1,8 @@
+int main(void) {
+    int big = 0x12345;
+    int x = (char)big;
+    int y = (unsigned char)-1;
+    int z = (short)0x18000;
+    unsigned short w = (unsigned short)-2;
+    return x + (y == 255) * 4 + (z == -32768) + (w == 65534) * 2;
+}
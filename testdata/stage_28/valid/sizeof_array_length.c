@@ -0,0 +1,7 @@
+int table[sizeof(int) * 2];
+int size = sizeof table / sizeof table[0];
+
+int main(void) {
+    char local[sizeof(short) + 1];
+    return size + sizeof local;
+}
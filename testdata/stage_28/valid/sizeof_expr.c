@@ -0,0 +1,7 @@
+int main(void) {
+    int arr[10];
+    char buf[7];
+    int *p = arr;
+    short s;
+    return sizeof arr + sizeof buf + sizeof p + sizeof(s) + sizeof arr[0] + sizeof "hello";
+}
@@ -0,0 +1,18 @@
+void *malloc(unsigned size);
+void free(void *p);
+
+struct point {
+    int x;
+    int y;
+};
+
+int main(void) {
+    struct point *pts = malloc(sizeof(struct point) * 3);
+    for (int i = 0; i < 3; i++) {
+        pts[i].x = i;
+        pts[i].y = i * i;
+    }
+    int sum = pts[2].x + pts[2].y + pts[1].y;
+    free(pts);
+    return sum;
+}
@@ -0,0 +1,12 @@
+int calls = 0;
+
+int touch(void) {
+    calls++;
+    return 1;
+}
+
+int main(void) {
+    int x = 1;
+    int n = sizeof(x++) + sizeof(touch());
+    return n + x * 10 + calls * 100;
+}
@@ -0,0 +1,11 @@
+struct node {
+    int value;
+    struct node *next;
+};
+
+int main(void) {
+    struct node nodes[4];
+    struct node *n = nodes;
+    int count = sizeof nodes / sizeof *n;
+    return count * 10 + sizeof n->next + sizeof(struct node *) * 0;
+}
@@ -0,0 +1,9 @@
+struct pair {
+    char c;
+    int i;
+};
+
+int main(void) {
+    return sizeof(char) + sizeof(short) * 10 + sizeof(int) * 100 / 4 + sizeof(long) - 4 +
+           sizeof(int *) + sizeof(struct pair) + sizeof(_Bool) + sizeof(int[3]);
+}
@@ -0,0 +1,3 @@
+int main(void) {
+    return (sizeof(int) - 5 > 0) + (sizeof(char) < -1) * 2;
+}
@@ -0,0 +1,3 @@
+int main(void) {
+    return missing(2);
+}
@@ -0,0 +1,9 @@
+int neg(int a) {
+    return -a;
+}
+
+int main(void) {
+    int (*f)(int, int);
+    f = neg;
+    return f(1, 2);
+}
@@ -0,0 +1,8 @@
+int add(int a, int b) {
+    return a + b;
+}
+
+int main(void) {
+    int (*f)(int) = add;
+    return f(1);
+}
@@ -0,0 +1,4 @@
+int main(void) {
+    int (*f(int);
+    return 0;
+}
@@ -0,0 +1,13 @@
+struct s {
+    int x;
+};
+
+int take(int x) {
+    return x;
+}
+
+int main(void) {
+    struct s v;
+    int (*f)(int) = take;
+    return f(v);
+}
@@ -0,0 +1,7 @@
+void nothing(void) {
+}
+
+int main(void) {
+    void (*f)(void) = nothing;
+    return f();
+}
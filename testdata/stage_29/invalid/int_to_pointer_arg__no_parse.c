@@ -0,0 +1,7 @@
+int add(int *a) {
+    return *a + 1;
+}
+
+int main(void) {
+    return add(5);
+}
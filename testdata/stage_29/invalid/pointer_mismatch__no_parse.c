@@ -0,0 +1,5 @@
+int main(void) {
+    char c = 'a';
+    int *p = &c;
+    return *p;
+}
@@ -0,0 +1,4 @@
+int main(void) {
+    char c = "abc";
+    return c;
+}
@@ -0,0 +1,8 @@
+int square(int x) {
+    return x * x;
+}
+
+int main(void) {
+    int (*f)(int) = &square;
+    return (*f)(5) + f(2) + (&square)(1);
+}
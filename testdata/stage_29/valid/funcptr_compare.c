@@ -0,0 +1,14 @@
+int a(void) {
+    return 1;
+}
+
+int b(void) {
+    return 2;
+}
+
+int main(void) {
+    int (*f)(void) = a;
+    int (*g)(void) = b;
+    void (*none)(void) = 0;
+    return (f == a) + (f != g) * 2 + (none == 0) * 4 + f() * 8 + g() * 16;
+}
@@ -0,0 +1,16 @@
+void *malloc(unsigned n);
+
+int twice(int a) {
+    return a * 2;
+}
+
+int main(void) {
+    int (*f)(int) = 0;
+    int *p = malloc(sizeof(int));
+    void *v = p;
+    _Bool b = p;
+    *p = 4;
+    if (!f)
+        f = &twice;
+    return f(*p) + (v == p) + b;
+}
@@ -0,0 +1,15 @@
+int apply(int (*f)(int), int x) {
+    return f(f(x));
+}
+
+int twice(int x) {
+    return x * 2;
+}
+
+int inc(int x) {
+    return x + 1;
+}
+
+int main(void) {
+    return apply(twice, 5) + apply(inc, 1);
+}
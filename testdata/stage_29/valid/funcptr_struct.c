@@ -0,0 +1,28 @@
+struct handler {
+    int code;
+    int (*run)(int);
+};
+
+int neg(int x) {
+    return -x;
+}
+
+int ident(int x) {
+    return x;
+}
+
+int dispatch(struct handler *h, int n, int code, int x) {
+    for (int i = 0; i < n; i++)
+        if (h[i].code == code)
+            return h[i].run(x);
+    return 0;
+}
+
+int main(void) {
+    struct handler hs[2];
+    hs[0].code = 1;
+    hs[0].run = neg;
+    hs[1].code = 2;
+    hs[1].run = ident;
+    return dispatch(hs, 2, 2, 40) + dispatch(hs, 2, 1, -2) + dispatch(hs, 2, 3, 9);
+}
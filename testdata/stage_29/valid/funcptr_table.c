@@ -0,0 +1,20 @@
+int add(int a, int b) {
+    return a + b;
+}
+
+int sub(int a, int b) {
+    return a - b;
+}
+
+int mul(int a, int b) {
+    return a * b;
+}
+
+int (*ops[3])(int, int) = {add, sub, mul};
+
+int main(void) {
+    int acc = 0;
+    for (int i = 0; i < 3; i++)
+        acc += ops[i](6, 3);
+    return acc;
+}
@@ -0,0 +1,27 @@
+typedef int (*binop)(int, int);
+
+int max(int a, int b) {
+    return a > b ? a : b;
+}
+
+int min(int a, int b) {
+    return a < b ? a : b;
+}
+
+int fold(binop f, int *xs, int n) {
+    int acc = xs[0];
+    for (int i = 1; i < n; i++)
+        acc = f(acc, xs[i]);
+    return acc;
+}
+
+binop pick(int largest) {
+    if (largest)
+        return max;
+    return min;
+}
+
+int main(void) {
+    int xs[5] = {4, 9, 2, 7, 5};
+    return fold(pick(1), xs, 5) * 10 + fold(pick(0), xs, 5);
+}
@@ -0,0 +1,16 @@
+int total = 0;
+
+void add_to_total(int n) {
+    total += n;
+}
+
+void each(int *xs, int n, void (*fn)(int)) {
+    for (int i = 0; i < n; i++)
+        fn(xs[i]);
+}
+
+int main(void) {
+    int xs[4] = {1, 2, 3, 4};
+    each(xs, 4, add_to_total);
+    return total;
+}
@@ -0,0 +1,6 @@
+int x;
+
+int main(void) {
+    extern char x;
+    return x;
+}
@@ -0,0 +1,6 @@
+static int x;
+int x;
+
+int main(void) {
+    return x;
+}
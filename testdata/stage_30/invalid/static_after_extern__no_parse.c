@@ -0,0 +1,6 @@
+extern int x;
+static int x;
+
+int main(void) {
+    return x;
+}
@@ -0,0 +1,8 @@
+int f(void);
+static int f(void) {
+    return 1;
+}
+
+int main(void) {
+    return f();
+}
@@ -0,0 +1,6 @@
+int main(void) {
+    int sum = 0;
+    for (static int i = 0; i < 3; i++)
+        sum += i;
+    return sum;
+}
@@ -0,0 +1,15 @@
+int get(void) {
+    extern int value;
+    return value;
+}
+
+int value = 12;
+
+int main(void) {
+    int value = 1;
+    {
+        extern int value;
+        value++;
+    }
+    return get() + value;
+}
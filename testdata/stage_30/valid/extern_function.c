@@ -0,0 +1,11 @@
+extern int puts(char *s);
+extern int square(int x);
+
+int main(void) {
+    puts("extern");
+    return square(7);
+}
+
+int square(int x) {
+    return x * x;
+}
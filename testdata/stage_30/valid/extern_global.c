@@ -0,0 +1,13 @@
+extern int value;
+extern int values[];
+
+int get(void) {
+    return value + values[2];
+}
+
+int value = 40;
+int values[3] = {0, 0, 2};
+
+int main(void) {
+    return get();
+}
@@ -0,0 +1,5 @@
+extern int value = 5;
+
+int main(void) {
+    return value;
+}
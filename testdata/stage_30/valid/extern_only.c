@@ -0,0 +1,6 @@
+extern int unused;
+extern int other(void);
+
+int main(void) {
+    return 3;
+}
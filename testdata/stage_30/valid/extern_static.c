@@ -0,0 +1,7 @@
+static int count = 4;
+extern int count;
+
+int main(void) {
+    extern int count;
+    return count;
+}
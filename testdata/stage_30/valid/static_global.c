@@ -0,0 +1,12 @@
+static int total;
+static int scale = 3;
+
+static int add(int x) {
+    total += x * scale;
+    return total;
+}
+
+int main(void) {
+    add(1);
+    return add(2);
+}
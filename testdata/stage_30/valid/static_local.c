@@ -0,0 +1,11 @@
+int counter(void) {
+    static int count;
+    count = count + 1;
+    return count;
+}
+
+int main(void) {
+    counter();
+    counter();
+    return counter();
+}
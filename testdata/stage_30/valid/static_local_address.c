@@ -0,0 +1,11 @@
+int *get(void) {
+    static int value;
+    static int *p = &value;
+    return p;
+}
+
+int main(void) {
+    *get() = 7;
+    *get() += 3;
+    return *get();
+}
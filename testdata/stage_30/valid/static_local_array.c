@@ -0,0 +1,11 @@
+int push(int x) {
+    static int stack[4] = {1};
+    static int len = 1;
+    stack[len++] = x;
+    return stack[0] + stack[len - 1];
+}
+
+int main(void) {
+    push(3);
+    return push(5);
+}
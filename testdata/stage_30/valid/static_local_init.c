@@ -0,0 +1,11 @@
+int next(void) {
+    static int value = 10;
+    static int step = 5;
+    value += step;
+    return value;
+}
+
+int main(void) {
+    next();
+    return next();
+}
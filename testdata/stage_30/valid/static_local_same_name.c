@@ -0,0 +1,20 @@
+int a(void) {
+    static int n = 1;
+    return n++;
+}
+
+int b(void) {
+    static int n = 100;
+    {
+        static int n = 20;
+        n++;
+    }
+    return n++;
+}
+
+int main(void) {
+    a();
+    a();
+    b();
+    return a() + b();
+}
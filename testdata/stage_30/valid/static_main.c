@@ -0,0 +1,5 @@
+int main(void) {
+    static int n = 4;
+    n *= 2;
+    return n;
+}
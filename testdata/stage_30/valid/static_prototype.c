@@ -0,0 +1,9 @@
+static int twice(int x);
+
+int twice(int x) {
+    return x * 2;
+}
+
+int main(void) {
+    return twice(21);
+}
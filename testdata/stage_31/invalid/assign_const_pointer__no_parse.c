@@ -0,0 +1,7 @@
+int main(void) {
+    int x = 1;
+    int y = 2;
+    int *const p = &x;
+    p = &y;
+    return *p;
+}
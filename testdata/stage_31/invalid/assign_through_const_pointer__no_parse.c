@@ -0,0 +1,6 @@
+int main(void) {
+    int x = 1;
+    const int *p = &x;
+    *p = 2;
+    return x;
+}
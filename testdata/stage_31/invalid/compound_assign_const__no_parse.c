@@ -0,0 +1,6 @@
+const int total = 5;
+
+int main(void) {
+    total += 1;
+    return total;
+}
@@ -0,0 +1,5 @@
+int main(void) {
+    const int a[2] = {1, 2};
+    a[0] = 3;
+    return a[0];
+}
@@ -0,0 +1,10 @@
+struct counter {
+    const int id;
+    int count;
+};
+
+int main(void) {
+    struct counter c = {1, 0};
+    c.id = 2;
+    return c.id;
+}
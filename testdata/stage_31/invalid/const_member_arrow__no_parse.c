@@ -0,0 +1,14 @@
+struct point {
+    int x;
+    int y;
+};
+
+int get(const struct point *p) {
+    p->y = 0;
+    return p->x;
+}
+
+int main(void) {
+    struct point p = {1, 2};
+    return get(&p);
+}
@@ -0,0 +1,10 @@
+struct point {
+    int x;
+    int y;
+};
+
+int main(void) {
+    const struct point p = {1, 2};
+    p.x = 3;
+    return p.x;
+}
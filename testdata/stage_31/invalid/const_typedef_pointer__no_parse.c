@@ -0,0 +1,8 @@
+typedef const int cint;
+
+int main(void) {
+    int x = 1;
+    cint *p = &x;
+    *p = 2;
+    return x;
+}
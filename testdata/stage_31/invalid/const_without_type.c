@@ -0,0 +1,4 @@
+int main(void) {
+    const x = 1;
+    return x;
+}
@@ -0,0 +1,10 @@
+int clear(int *p) {
+    *p = 0;
+    return 0;
+}
+
+int main(void) {
+    const int x = 1;
+    const int *p = &x;
+    return clear(p);
+}
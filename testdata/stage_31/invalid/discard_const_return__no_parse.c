@@ -0,0 +1,9 @@
+const int limit = 3;
+
+int *get(void) {
+    return &limit;
+}
+
+int main(void) {
+    return *get();
+}
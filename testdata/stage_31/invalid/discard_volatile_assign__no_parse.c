@@ -0,0 +1,6 @@
+int main(void) {
+    volatile int x = 1;
+    int *p;
+    p = &x;
+    return *p;
+}
@@ -0,0 +1,5 @@
+int main(void) {
+    const int x = 1;
+    x++;
+    return x;
+}
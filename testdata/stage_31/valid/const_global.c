@@ -0,0 +1,12 @@
+const int table[4] = {1, 2, 3, 4};
+const char *const message = "hi";
+static const int offset = 3;
+
+int lookup(void) {
+    static const int index = 2;
+    return table[index];
+}
+
+int main(void) {
+    return lookup() + offset + message[0];
+}
@@ -0,0 +1,6 @@
+int main(void) {
+    const int x = 5;
+    const int *p = &x;
+    int y = *p;
+    return x + y;
+}
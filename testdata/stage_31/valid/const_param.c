@@ -0,0 +1,13 @@
+int sum(const int *a, int n);
+
+int sum(const int *a, const int n) {
+    int s = 0;
+    for (int i = 0; i < n; i++)
+        s += a[i];
+    return s;
+}
+
+int main(void) {
+    int values[3] = {4, 5, 6};
+    return sum(values, 3);
+}
@@ -0,0 +1,9 @@
+int main(void) {
+    int a = 1;
+    int b = 2;
+    int *const p = &a;
+    const int *q = &a;
+    *p = 7;
+    q = &b;
+    return *p + *q;
+}
@@ -0,0 +1,7 @@
+int main(void) {
+    int const a = 2;
+    const volatile unsigned b = 3;
+    unsigned short const c = 4;
+    const char *s = (const char *)"xyz";
+    return a + b + c + sizeof(const int) + s[1];
+}
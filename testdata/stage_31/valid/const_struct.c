@@ -0,0 +1,11 @@
+struct point {
+    int x;
+    int y;
+};
+
+int main(void) {
+    const struct point p = {3, 4};
+    struct point q = p;
+    q.x = 10;
+    return p.x + q.x + q.y;
+}
@@ -0,0 +1,10 @@
+typedef const int cint;
+typedef int *ptr;
+
+int main(void) {
+    int y = 1;
+    cint x = 3;
+    const ptr p = &y;
+    *p = 4;
+    return x + y;
+}
@@ -0,0 +1,17 @@
+volatile int status;
+
+int poll(volatile int *reg) {
+    int n = 0;
+    while (*reg < 5) {
+        *reg = *reg + 1;
+        n++;
+    }
+    return n;
+}
+
+int main(void) {
+    volatile int local = 2;
+    volatile int *volatile p = &local;
+    status = 1;
+    return poll(&status) + poll(p) + *p;
+}
@@ -0,0 +1,5 @@
+int f(...);
+
+int main(void) {
+    return 0;
+}
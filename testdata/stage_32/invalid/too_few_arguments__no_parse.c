@@ -0,0 +1,6 @@
+int printf(const char *format, ...);
+
+int main(void) {
+    printf();
+    return 0;
+}
@@ -0,0 +1,11 @@
+int f(int a, ...) {
+    va_list ap;
+    va_start(ap, a);
+    char c = va_arg(ap, char);
+    va_end(ap);
+    return c;
+}
+
+int main(void) {
+    return f(1, 'a');
+}
@@ -0,0 +1,9 @@
+int f(int a, ...) {
+    va_list ap;
+    va_start(ap, a);
+    return va_arg(ap);
+}
+
+int main(void) {
+    return f(1, 2);
+}
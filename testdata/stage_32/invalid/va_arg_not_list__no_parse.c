@@ -0,0 +1,8 @@
+int f(int a, ...) {
+    int ap = 0;
+    return va_arg(ap, int);
+}
+
+int main(void) {
+    return f(1, 2);
+}
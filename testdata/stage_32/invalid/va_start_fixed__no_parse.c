@@ -0,0 +1,10 @@
+int f(int a) {
+    va_list ap;
+    va_start(ap, a);
+    va_end(ap);
+    return a;
+}
+
+int main(void) {
+    return f(1);
+}
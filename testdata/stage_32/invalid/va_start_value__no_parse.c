@@ -0,0 +1,9 @@
+int f(int a, ...) {
+    va_list ap;
+    int x = va_start(ap, a);
+    return x;
+}
+
+int main(void) {
+    return f(1, 2);
+}
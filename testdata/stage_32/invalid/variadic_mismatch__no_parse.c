@@ -0,0 +1,9 @@
+int f(int a, ...);
+
+int f(int a) {
+    return a;
+}
+
+int main(void) {
+    return f(1);
+}
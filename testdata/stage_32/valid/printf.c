@@ -0,0 +1,10 @@
+int printf(const char *format, ...);
+
+int main(void) {
+    char c = 'x';
+    short s = -3;
+    unsigned u = 4000000000u;
+    printf("%d %c %d %u %s\n", 42, c, s, u, "str");
+    printf("done\n");
+    return 7;
+}
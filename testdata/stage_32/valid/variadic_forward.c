@@ -0,0 +1,21 @@
+int vmax(int count, va_list ap) {
+    int max = va_arg(ap, int);
+    while (--count) {
+        int v = va_arg(ap, int);
+        if (v > max)
+            max = v;
+    }
+    return max;
+}
+
+int max(int count, ...) {
+    va_list ap;
+    va_start(ap, count);
+    int result = vmax(count, ap);
+    va_end(ap);
+    return result;
+}
+
+int main(void) {
+    return max(5, 3, 9, 27, 4, 1);
+}
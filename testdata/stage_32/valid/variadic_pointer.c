@@ -0,0 +1,12 @@
+int first(int n, ...) {
+    va_list ap;
+    va_start(ap, n);
+    int v = va_arg(ap, int);
+    va_end(ap);
+    return v + n;
+}
+
+int main(void) {
+    int (*fp)(int, ...) = first;
+    return fp(1, 10, 20) + first(2, 30);
+}
@@ -0,0 +1,17 @@
+int sum(int count, ...) {
+    va_list ap;
+    int total = 0;
+    va_start(ap, count);
+    while (count--)
+        total += va_arg(ap, int);
+    va_end(ap);
+    return total;
+}
+
+int main(void) {
+    char c = -2;
+    unsigned char uc = 200;
+    short s = -30;
+    _Bool b = 1;
+    return sum(4, c, uc, s, b);
+}
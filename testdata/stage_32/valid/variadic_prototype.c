@@ -0,0 +1,15 @@
+int pick(int index, ...);
+
+int main(void) {
+    return pick(2, 10, 20, 30);
+}
+
+int pick(int index, ...) {
+    va_list ap;
+    int v = 0;
+    va_start(ap, index);
+    for (int i = 0; i <= index; i++)
+        v = va_arg(ap, int);
+    va_end(ap);
+    return v;
+}
@@ -0,0 +1,13 @@
+int sum(int count, ...) {
+    va_list ap;
+    int total = 0;
+    va_start(ap, count);
+    for (int i = 0; i < count; i++)
+        total += va_arg(ap, int);
+    va_end(ap);
+    return total;
+}
+
+int main(void) {
+    return sum(0) + sum(1, 2) + sum(4, 1, 2, 3, 4);
+}
@@ -0,0 +1,30 @@
+struct pair {
+    int a;
+    char b;
+};
+
+int collect(char *kinds, ...) {
+    va_list ap;
+    int total = 0;
+    va_start(ap, kinds);
+    for (; *kinds; kinds++) {
+        if (*kinds == 'i') {
+            total += va_arg(ap, int);
+        } else if (*kinds == 's') {
+            char *s = va_arg(ap, char *);
+            total += s[0];
+        } else if (*kinds == 'p') {
+            struct pair p = va_arg(ap, struct pair);
+            total += p.a * p.b;
+        } else if (*kinds == 'u') {
+            total += va_arg(ap, unsigned) / 1000;
+        }
+    }
+    va_end(ap);
+    return total;
+}
+
+int main(void) {
+    struct pair p = {3, 4};
+    return collect("ispu", 5, "a", p, 2000u);
+}
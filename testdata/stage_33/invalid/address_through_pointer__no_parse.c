@@ -0,0 +1,7 @@
+int x[3];
+int *p = x;
+int *q = &p[1];
+
+int main(void) {
+    return *q;
+}
@@ -0,0 +1,7 @@
+int x[3];
+int i = 1;
+int *q = &x[i];
+
+int main(void) {
+    return *q;
+}
@@ -0,0 +1,9 @@
+struct point {
+    int x;
+    int y;
+};
+
+int main(void) {
+    int *p = (struct point){1, 2};
+    return *p;
+}
@@ -0,0 +1,9 @@
+struct point {
+    int x;
+    int y;
+};
+
+int main(void) {
+    struct point p = {.x 1};
+    return p.x;
+}
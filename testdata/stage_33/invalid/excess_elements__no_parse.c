@@ -0,0 +1,9 @@
+struct point {
+    int x;
+    int y;
+};
+
+int main(void) {
+    struct point p = {.y = 1, 2};
+    return p.x;
+}
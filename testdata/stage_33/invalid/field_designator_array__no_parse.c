@@ -0,0 +1,4 @@
+int main(void) {
+    int a[2] = {.x = 1};
+    return a[0];
+}
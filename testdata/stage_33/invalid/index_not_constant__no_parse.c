@@ -0,0 +1,5 @@
+int main(void) {
+    int i = 1;
+    int a[2] = {[i] = 1};
+    return a[0];
+}
@@ -0,0 +1,5 @@
+int a[4] = {[4] = 1};
+
+int main(void) {
+    return a[0];
+}
@@ -0,0 +1,4 @@
+int main(void) {
+    static int *p = (int[]){1, 2};
+    return p[0];
+}
@@ -0,0 +1,5 @@
+char s[3] = "abcd";
+
+int main(void) {
+    return s[0];
+}
@@ -0,0 +1,24 @@
+struct point {
+    int x;
+    int y;
+};
+
+struct shape {
+    int sides;
+    struct point pts[3];
+};
+
+int x[3] = {1, 2, 3};
+int *q = &x[2];
+struct point s = {4, 5};
+int *p = &s.y;
+struct shape tri = {3, {{0, 0}, {6, 7}, {8, 9}}};
+int *corner = &tri.pts[1].y;
+struct point *last = &tri.pts[2];
+int m[2][3] = {{1, 2, 3}, {4, 5, 6}};
+int *cell = &m[1][2];
+int *mrow = m[1];
+
+int main(void) {
+    return *q + *p + *corner + last->x + *cell + mrow[0];
+}
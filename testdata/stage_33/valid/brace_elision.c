@@ -0,0 +1,21 @@
+struct point {
+    int x;
+    int y;
+};
+
+struct line {
+    struct point from;
+    struct point to;
+};
+
+int m[2][3] = {1, 2, 3, 4, 5};
+
+int main(void) {
+    struct line l = {1, 2, {3}, };
+    int n[2][3] = {1, 2, 3, 4};
+    struct point pts[] = {1, 2, 3, 4, 5};
+    return l.from.x + l.from.y + l.to.x + l.to.y
+        + m[1][1] * 10 + m[1][2]
+        + n[1][0] * 100 + n[1][2]
+        + sizeof(pts) / sizeof(pts[0]) * 1000 % 256;
+}
@@ -0,0 +1,24 @@
+struct point {
+    int x;
+    int y;
+};
+
+int dist(struct point *p) {
+    return p->x + p->y;
+}
+
+int sum(int *a, int n) {
+    int s = 0;
+    for (int i = 0; i < n; i++)
+        s += a[i];
+    return s;
+}
+
+int main(void) {
+    struct point p = (struct point){1, 2};
+    struct point *q = &(struct point){.y = 10};
+    q->x = 20;
+    int n = dist(&(struct point){3, 4}) + (struct point){.x = 5}.x;
+    (struct point){0}.y = 7;
+    return p.x + p.y + q->x + q->y + n + sum((int[]){1, 2, 3}, 3);
+}
@@ -0,0 +1,13 @@
+struct point {
+    int x;
+    int y;
+};
+
+struct point *origin = &(struct point){3, 4};
+int *primes = (int[]){2, 3, 5, 7};
+struct point pts[2] = {(struct point){1, 2}, [1].y = 6};
+
+int main(void) {
+    origin->x++;
+    return origin->x + origin->y + primes[3] + pts[0].x + pts[0].y + pts[1].y;
+}
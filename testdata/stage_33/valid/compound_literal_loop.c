@@ -0,0 +1,14 @@
+struct point {
+    int x;
+    int y;
+};
+
+int main(void) {
+    int total = 0;
+    for (int i = 0; i < 4; i++) {
+        struct point *p = &(struct point){i};
+        total += p->x + p->y;
+        p->y = 100;
+    }
+    return total;
+}
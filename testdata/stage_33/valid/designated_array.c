@@ -0,0 +1,7 @@
+int main(void) {
+    int a[10] = {[3] = 7, 8, [8] = 1};
+    int sum = 0;
+    for (int i = 0; i < 10; i++)
+        sum = sum * 2 + a[i];
+    return sum % 256;
+}
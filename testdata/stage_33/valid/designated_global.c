@@ -0,0 +1,12 @@
+struct point {
+    int x;
+    int y;
+};
+
+struct point origin = {.y = 4};
+int table[8] = {[1] = 2, [6] = 5, 9};
+char name[] = {[2] = 'c', 'd'};
+
+int main(void) {
+    return origin.x + origin.y + table[1] + table[6] + table[7] + sizeof(name) + name[3] - name[2];
+}
@@ -0,0 +1,4 @@
+int main(void) {
+    int m[2][2] = {[1][0] = 3, [0] = {1, 2}};
+    return m[0][1] + m[1][0];
+}
@@ -0,0 +1,5 @@
+int main(void) {
+    int a[4] = {1, 2, 3, 4, [1] = 20, 30};
+    int b[] = {[5] = 1, [2] = 3};
+    return a[0] + a[1] + a[2] + a[3] + sizeof(b) / sizeof(int) + b[2];
+}
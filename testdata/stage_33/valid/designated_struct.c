@@ -0,0 +1,11 @@
+struct point {
+    int x;
+    int y;
+    int z;
+};
+
+int main(void) {
+    struct point p = {.y = 2, .x = 1};
+    struct point q = {.y = 20, 30};
+    return p.x + p.y * 10 + p.z + q.x + q.y + q.z;
+}
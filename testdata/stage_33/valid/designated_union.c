@@ -0,0 +1,15 @@
+union value {
+    char c;
+    int i;
+};
+
+struct tagged {
+    int kind;
+    union value v;
+};
+
+int main(void) {
+    union value u = {.i = 258};
+    struct tagged t = {1, .v.c = 5};
+    return u.i + t.kind + t.v.c;
+}
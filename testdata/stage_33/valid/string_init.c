@@ -0,0 +1,16 @@
+int puts(const char *s);
+
+struct name {
+    char first[8];
+    int len;
+};
+
+int main(void) {
+    char s[] = "hello";
+    char t[8] = {"abc"};
+    struct name n = {"bob", 3};
+    puts(s);
+    puts(t);
+    puts(n.first);
+    return sizeof(s) + t[7] + n.len;
+}
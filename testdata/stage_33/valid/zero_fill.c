@@ -0,0 +1,17 @@
+struct s {
+    char c;
+    short h;
+    int i;
+    int a[3];
+};
+
+struct s g = {1};
+
+int main(void) {
+    int x = 1;
+    struct s l = {.c = 2, .a[1] = 5};
+    int arr[5] = {x};
+    return g.c + g.h + g.i + g.a[2]
+        + l.c + l.h + l.i + l.a[0] + l.a[1] + l.a[2]
+        + arr[0] + arr[1] + arr[4];
+}
@@ -18,6 +18,14 @@ type Token struct {
 	Pos  Pos
 	Type TokenType
 	Text string
+
+	// Radix and Suffix are set on INT_LIT tokens.
+	// The Suffix is the trailing combination of u, l, and ll.
+	Radix  int
+	Suffix string
+
+	// Value is the decoded contents of CHAR_LIT and STRING tokens.
+	Value string
 }
 
 func New(typ TokenType, text string) Token {
@@ -51,7 +59,11 @@ const (
 	RBRACE    = "RBRACE"
 	SEMICOLON = "SEMICOLON"
 	INT_LIT   = "INT_LIT"
+	CHAR_LIT  = "CHAR_LIT"
+	STRING    = "STRING"
 	INT_TYPE  = "INT_TYPE"
+	VOID_TYPE = "VOID_TYPE"
+	CHAR_TYPE = "CHAR_TYPE"
 	RETURN    = "RETURN"
 	MINUS     = "MINUS"
 	PLUS      = "PLUS"
@@ -79,11 +91,57 @@ const (
 	PERCENT   = "PERCENT"
 	CONTINUE  = "CONTINUE"
 	COMMA     = "COMMA"
+	STRUCT    = "STRUCT"
+	UNION     = "UNION"
+	ENUM      = "ENUM"
+	TYPEDEF   = "TYPEDEF"
+	STATIC    = "STATIC"
+	EXTERN    = "EXTERN"
+	CONST     = "CONST"
+	VOLATILE  = "VOLATILE"
+	SWITCH    = "SWITCH"
+	CASE      = "CASE"
+	DEFAULT   = "DEFAULT"
+	GOTO      = "GOTO"
+	SIZEOF    = "SIZEOF"
+	VA_START  = "VA_START"
+	VA_ARG    = "VA_ARG"
+	VA_END    = "VA_END"
+
+	SHORT_TYPE = "SHORT_TYPE"
+	LONG_TYPE  = "LONG_TYPE"
+	BOOL_TYPE  = "BOOL_TYPE"
+	SIGNED     = "SIGNED"
+	UNSIGNED   = "UNSIGNED"
+
+	AMPERSAND    = "AMPERSAND"
+	PIPE         = "PIPE"
+	CARET        = "CARET"
+	SHL          = "SHL"
+	SHR          = "SHR"
+	INC          = "INC"
+	DEC          = "DEC"
+	PLUS_EQ      = "PLUS_EQ"
+	MINUS_EQ     = "MINUS_EQ"
+	ASTERISK_EQ  = "ASTERISK_EQ"
+	SLASH_EQ     = "SLASH_EQ"
+	PERCENT_EQ   = "PERCENT_EQ"
+	AMPERSAND_EQ = "AMPERSAND_EQ"
+	PIPE_EQ      = "PIPE_EQ"
+	CARET_EQ     = "CARET_EQ"
+	SHL_EQ       = "SHL_EQ"
+	SHR_EQ       = "SHR_EQ"
+	ARROW        = "ARROW"
+	DOT          = "DOT"
+	LBRACKET     = "LBRACKET"
+	RBRACKET     = "RBRACKET"
+	ELLIPSIS     = "ELLIPSIS"
 )
 
 var Keywords = map[string]TokenType{
 	"return":   RETURN,
 	"int":      INT_TYPE,
+	"void":     VOID_TYPE,
 	"if":       IF,
 	"else":     ELSE,
 	"do":       DO,
@@ -91,4 +149,26 @@ var Keywords = map[string]TokenType{
 	"for":      FOR,
 	"break":    BREAK,
 	"continue": CONTINUE,
+	"struct":   STRUCT,
+	"union":    UNION,
+	"enum":     ENUM,
+	"typedef":  TYPEDEF,
+	"static":   STATIC,
+	"extern":   EXTERN,
+	"const":    CONST,
+	"volatile": VOLATILE,
+	"char":     CHAR_TYPE,
+	"short":    SHORT_TYPE,
+	"long":     LONG_TYPE,
+	"signed":   SIGNED,
+	"unsigned": UNSIGNED,
+	"_Bool":    BOOL_TYPE,
+	"switch":   SWITCH,
+	"case":     CASE,
+	"default":  DEFAULT,
+	"goto":     GOTO,
+	"sizeof":   SIZEOF,
+	"va_start": VA_START,
+	"va_arg":   VA_ARG,
+	"va_end":   VA_END,
 }
@@ -0,0 +1,497 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Type is a C type on the i386 target.
+type Type interface {
+	Size() int
+	String() string
+}
+
+// Basic is an integer type. Plain char is signed on i386.
+type Basic int
+
+const (
+	Char Basic = iota
+	Int
+	UInt
+	Long
+	ULong
+	LongLong
+	ULongLong
+	Bool
+	SChar
+	UChar
+	Short
+	UShort
+)
+
+var basicNames = map[Basic]string{
+	Char:      "char",
+	Int:       "int",
+	UInt:      "unsigned int",
+	Long:      "long",
+	ULong:     "unsigned long",
+	LongLong:  "long long",
+	ULongLong: "unsigned long long",
+	Bool:      "_Bool",
+	SChar:     "signed char",
+	UChar:     "unsigned char",
+	Short:     "short",
+	UShort:    "unsigned short",
+}
+
+func (b Basic) String() string { return basicNames[b] }
+
+func (b Basic) Size() int {
+	switch b {
+	case Bool, Char, SChar, UChar:
+		return 1
+	case Short, UShort:
+		return 2
+	case LongLong, ULongLong:
+		return 8
+	default:
+		return 4
+	}
+}
+
+func (b Basic) Unsigned() bool {
+	switch b {
+	case Bool, UChar, UShort, UInt, ULong, ULongLong:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank returns the integer conversion rank of the type.
+func (b Basic) Rank() int {
+	switch b {
+	case Bool:
+		return 0
+	case Char, SChar, UChar:
+		return 1
+	case Short, UShort:
+		return 2
+	case Int, UInt:
+		return 3
+	case Long, ULong:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// toUnsigned returns the unsigned type corresponding to b.
+func (b Basic) toUnsigned() Basic {
+	switch b {
+	case Char, SChar:
+		return UChar
+	case Short:
+		return UShort
+	case Int:
+		return UInt
+	case Long:
+		return ULong
+	case LongLong:
+		return ULongLong
+	default:
+		return b
+	}
+}
+
+// Max returns the largest value representable by the type.
+func (b Basic) Max() uint64 {
+	bits := uint(b.Size() * 8)
+	if b.Unsigned() {
+		return 1<<bits - 1
+	}
+	return 1<<(bits-1) - 1
+}
+
+// Pointer is a pointer to a value of type Elem.
+type Pointer struct {
+	Elem Type
+}
+
+func (p *Pointer) Size() int      { return 4 }
+func (p *Pointer) String() string { return p.Elem.String() + "*" }
+
+// Array is an array of Len elements. Len is -1 when the size is unknown.
+// Lengths which aren't integer literals are kept in LenExpr until the
+// compiler evaluates them.
+type Array struct {
+	Elem    Type
+	Len     int
+	LenExpr fmt.Stringer
+}
+
+func (a *Array) Size() int {
+	if a.Len < 0 {
+		return 0
+	}
+	return a.Len * a.Elem.Size()
+}
+
+func (a *Array) String() string {
+	switch {
+	case a.LenExpr != nil:
+		return fmt.Sprintf("%s[%s]", a.Elem, a.LenExpr)
+	case a.Len < 0:
+		return fmt.Sprintf("%s[]", a.Elem)
+	default:
+		return fmt.Sprintf("%s[%d]", a.Elem, a.Len)
+	}
+}
+
+// Struct is a struct or union type. Each definition creates a distinct
+// type, so structs are compared by identity. Fields is empty until the
+// definition has been seen, and the offsets are computed by Layout once
+// the field types are complete.
+type Struct struct {
+	Tag     string
+	Union   bool
+	Fields  []*Field
+	Defined bool
+
+	laidOut     bool
+	size, align int
+}
+
+// Field is a struct member.
+type Field struct {
+	Name   string
+	Type   Type
+	Offset int
+}
+
+func (s *Struct) Size() int { return s.size }
+
+func (s *Struct) String() string {
+	kind := "struct"
+	if s.Union {
+		kind = "union"
+	}
+	if s.Tag == "" {
+		return kind + " <anonymous>"
+	}
+	return kind + " " + s.Tag
+}
+
+// Field returns the member with the provided name or nil.
+func (s *Struct) Field(name string) *Field {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// LaidOut reports whether Layout has been called.
+func (s *Struct) LaidOut() bool { return s.laidOut }
+
+// Layout assigns the field offsets and computes the size and alignment
+// using the i386 System V rules. Union members all have offset 0.
+func (s *Struct) Layout() {
+	s.size, s.align = 0, 1
+	for _, f := range s.Fields {
+		align := Align(f.Type)
+		if align > s.align {
+			s.align = align
+		}
+		if s.Union {
+			f.Offset = 0
+			if size := f.Type.Size(); size > s.size {
+				s.size = size
+			}
+			continue
+		}
+		f.Offset = roundUp(s.size, align)
+		s.size = f.Offset + f.Type.Size()
+	}
+	s.size = roundUp(s.size, s.align)
+	s.laidOut = true
+}
+
+func roundUp(n, align int) int {
+	return (n + align - 1) / align * align
+}
+
+// Align returns the alignment of t. On i386 no scalar is aligned to
+// more than 4 bytes.
+func Align(t Type) int {
+	switch t := Unqualified(t).(type) {
+	case *Array:
+		return Align(t.Elem)
+	case *Struct:
+		return t.align
+	case *Func:
+		return 1
+	default:
+		if size := t.Size(); size < 4 {
+			return size
+		}
+		return 4
+	}
+}
+
+// Func is a function type. Functions have no size in C, but GCC
+// treats it as 1 for pointer arithmetic and so do we. Variadic
+// functions take any number of arguments after Params.
+type Func struct {
+	Result   Type
+	Params   []Type
+	Variadic bool
+}
+
+func (f *Func) Size() int { return 1 }
+func (f *Func) String() string {
+	params := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		params[i] = p.String()
+	}
+	if f.Variadic {
+		params = append(params, "...")
+	}
+	return fmt.Sprintf("%s(%s)", f.Result, strings.Join(params, ", "))
+}
+
+// VaList is the type of va_list. It points to the next variadic
+// argument on the stack.
+var VaList Type = &Pointer{Elem: Char}
+
+// void is the result type of functions which don't return a value.
+// Like functions it is given a size of 1 for pointer arithmetic.
+type void struct{}
+
+// Void is the void type.
+var Void Type = void{}
+
+func (void) Size() int      { return 1 }
+func (void) String() string { return "void" }
+
+// Qualified is a const or volatile qualified type. Qualifiers are
+// only used to check assignments; every access to an object is a
+// load or store, so volatile needs no special treatment.
+type Qualified struct {
+	Type     Type
+	Const    bool
+	Volatile bool
+}
+
+func (q *Qualified) Size() int { return q.Type.Size() }
+func (q *Qualified) String() string {
+	var quals []string
+	if q.Const {
+		quals = append(quals, "const")
+	}
+	if q.Volatile {
+		quals = append(quals, "volatile")
+	}
+	// qualifiers of a pointer follow the asterisk.
+	if _, ok := q.Type.(*Pointer); ok {
+		return q.Type.String() + " " + strings.Join(quals, " ")
+	}
+	return strings.Join(quals, " ") + " " + q.Type.String()
+}
+
+// Qualify adds qualifiers to t. Qualifying an array type qualifies
+// its elements instead.
+func Qualify(t Type, isConst, isVolatile bool) Type {
+	if !isConst && !isVolatile {
+		return t
+	}
+	switch t := t.(type) {
+	case *Qualified:
+		return &Qualified{
+			Type:     t.Type,
+			Const:    t.Const || isConst,
+			Volatile: t.Volatile || isVolatile,
+		}
+	case *Array:
+		return &Array{
+			Elem:    Qualify(t.Elem, isConst, isVolatile),
+			Len:     t.Len,
+			LenExpr: t.LenExpr,
+		}
+	}
+	return &Qualified{Type: t, Const: isConst, Volatile: isVolatile}
+}
+
+// Unqualified returns t without its top level qualifiers.
+func Unqualified(t Type) Type {
+	if q, ok := t.(*Qualified); ok {
+		return q.Type
+	}
+	return t
+}
+
+// IsConst reports whether objects of type t are read-only. Arrays
+// are read-only when their elements are.
+func IsConst(t Type) bool {
+	switch t := t.(type) {
+	case *Qualified:
+		return t.Const || IsConst(t.Type)
+	case *Array:
+		return IsConst(t.Elem)
+	default:
+		return false
+	}
+}
+
+// IsVolatile reports whether objects of type t are volatile. Arrays
+// are volatile when their elements are.
+func IsVolatile(t Type) bool {
+	switch t := t.(type) {
+	case *Qualified:
+		return t.Volatile || IsVolatile(t.Type)
+	case *Array:
+		return IsVolatile(t.Elem)
+	default:
+		return false
+	}
+}
+
+// Identical reports whether x and y are the same type.
+func Identical(x, y Type) bool {
+	switch x := x.(type) {
+	case Basic, void:
+		return x == y
+	case *Qualified:
+		y, ok := y.(*Qualified)
+		return ok && x.Const == y.Const && x.Volatile == y.Volatile && Identical(x.Type, y.Type)
+	case *Pointer:
+		y, ok := y.(*Pointer)
+		return ok && Identical(x.Elem, y.Elem)
+	case *Array:
+		y, ok := y.(*Array)
+		return ok && x.Len == y.Len && Identical(x.Elem, y.Elem)
+	case *Struct:
+		return x == y
+	case *Func:
+		y, ok := y.(*Func)
+		if !ok || len(x.Params) != len(y.Params) || x.Variadic != y.Variadic || !Identical(x.Result, y.Result) {
+			return false
+		}
+		// the qualifiers of parameters don't affect the function type.
+		for i := range x.Params {
+			if !Identical(Unqualified(x.Params[i]), Unqualified(y.Params[i])) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// IsInteger reports whether t is an integer type.
+func IsInteger(t Type) bool {
+	_, ok := Unqualified(t).(Basic)
+	return ok
+}
+
+// IsPointer reports whether t is a pointer type.
+func IsPointer(t Type) bool {
+	_, ok := Unqualified(t).(*Pointer)
+	return ok
+}
+
+// IsUnsigned reports whether values of type t are compared as unsigned.
+func IsUnsigned(t Type) bool {
+	switch t := Unqualified(t).(type) {
+	case Basic:
+		return t.Unsigned()
+	case *Pointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsArray reports whether t is an array type.
+func IsArray(t Type) bool {
+	_, ok := Unqualified(t).(*Array)
+	return ok
+}
+
+// Decay converts array types to pointers to their first element and
+// function types to pointers to the function. Like any other rvalue
+// the result is unqualified.
+func Decay(t Type) Type {
+	switch t := Unqualified(t).(type) {
+	case *Array:
+		return &Pointer{Elem: t.Elem}
+	case *Func:
+		return &Pointer{Elem: t}
+	default:
+		return t
+	}
+}
+
+// IsStruct reports whether t is a struct or union type.
+func IsStruct(t Type) bool {
+	_, ok := Unqualified(t).(*Struct)
+	return ok
+}
+
+// IsComplete reports whether the size of t is known.
+func IsComplete(t Type) bool {
+	switch t := Unqualified(t).(type) {
+	case *Array:
+		return (t.Len >= 0 || t.LenExpr != nil) && IsComplete(t.Elem)
+	case *Struct:
+		return t.Defined
+	case void:
+		return false
+	default:
+		return true
+	}
+}
+
+// Promote applies the integer promotions. Integer types with a rank
+// lower than int are converted to int since it can represent all of
+// their values. Other types are returned unchanged.
+func Promote(t Type) Type {
+	t = Unqualified(t)
+	if b, ok := t.(Basic); ok && b.Rank() < Int.Rank() {
+		return Int
+	}
+	return t
+}
+
+// Arith returns the common type of the operands of an arithmetic
+// operator according to the usual arithmetic conversions.
+func Arith(x, y Type) Type {
+	bx, ok1 := Promote(x).(Basic)
+	by, ok2 := Promote(y).(Basic)
+	if !ok1 || !ok2 {
+		return Int
+	}
+	switch {
+	case bx == by:
+		return bx
+	case bx.Unsigned() == by.Unsigned():
+		if bx.Rank() > by.Rank() {
+			return bx
+		}
+		return by
+	}
+	u, s := bx, by
+	if s.Unsigned() {
+		u, s = s, u
+	}
+	switch {
+	case u.Rank() >= s.Rank():
+		return u
+	case s.Size() > u.Size():
+		return s
+	default:
+		return s.toUnsigned()
+	}
+}